@@ -1,12 +1,13 @@
 package boot
 
 import (
-	"os"
-	"os/signal"
+	"context"
+	"fmt"
 	"runtime"
-	"sync/atomic"
+	"time"
 
 	"github.com/gopherd/doge/operator"
+	"github.com/gopherd/three/core"
 	"github.com/gopherd/three/driver/renderer"
 	"github.com/gopherd/three/driver/window"
 )
@@ -26,8 +27,26 @@ type Options struct {
 	Width  int
 	Height int
 
+	// Window is window.Default() if not set, Renderer is NullRenderer for
+	// HeadlessWindow and OpenGLRenderer for others if not set
 	Window   window.Window
 	Renderer renderer.Renderer
+	// Logger is used by all subsystems if set, e.g. a *slog.Logger,
+	// see core.SetLogger
+	Logger core.Logger
+	// WindowOptions configures creating window, Title, Width, Height and
+	// DisableVSync of Options are used if they're not set
+	WindowOptions window.Options
+
+	// FixedTimestep enables fixed-timestep loop if positive, the application
+	// must implement FixedApplication, otherwise Update is called per frame
+	FixedTimestep time.Duration
+	// MaxUpdateSteps limits number of fixed updates per frame, default 5
+	MaxUpdateSteps int
+	// MaxFPS caps frame rate if positive
+	MaxFPS int
+	// DisableVSync disables synchronizing buffer swaps with monitor refresh
+	DisableVSync bool
 
 	Start func()
 }
@@ -36,49 +55,53 @@ func (options *Options) init() {
 	options.Title = operator.Or(options.Title, "Title")
 	options.Width = operator.Or(options.Width, 800)
 	options.Height = operator.Or(options.Height, 600)
-	options.Window = operator.OrNew(options.Window, window.GLFWindow)
+	options.MaxUpdateSteps = operator.Or(options.MaxUpdateSteps, 5)
+	options.Window = operator.OrNew(options.Window, window.Default)
+
+	var windowOptions = &options.WindowOptions
+	windowOptions.Title = operator.Or(windowOptions.Title, options.Title)
+	windowOptions.Width = operator.Or(windowOptions.Width, options.Width)
+	windowOptions.Height = operator.Or(windowOptions.Height, options.Height)
+	windowOptions.DisableVSync = windowOptions.DisableVSync || options.DisableVSync
+	if _, ok := options.Window.(*window.HeadlessWindow); ok {
+		// headless windows create no graphics context
+		options.Renderer = operator.OrNew(options.Renderer, renderer.NullRenderer)
+	}
 	options.Renderer = operator.OrNew(options.Renderer, renderer.OpenGLRenderer)
 }
 
-func Run(app Application, options Options) {
+// Run runs the application until the window closed or ctx canceled, errors
+// of initializing and ctx.Err() if ctx canceled are returned, errors of
+// updating and rendering are reported by core.ReportError
+func Run(ctx context.Context, app Application, options Options) error {
 	options.init()
-	if err := options.Window.Init(
-		options.Renderer,
-		options.Title,
-		options.Width,
-		options.Height,
-	); err != nil {
-		panic(err)
+	if options.Logger != nil {
+		core.SetLogger(options.Logger)
+	}
+	if err := options.Window.Init(options.Renderer, options.WindowOptions); err != nil {
+		return fmt.Errorf("boot: init window: %w", err)
 	}
 	defer options.Window.Terminate()
 
-	if err := options.Renderer.Init(options.Width, options.Height); err != nil {
-		panic(err)
+	var width, height = options.Window.GetFramebufferSize()
+	if err := options.Renderer.Init(width, height); err != nil {
+		return fmt.Errorf("boot: init renderer: %w", err)
 	}
 
 	if err := app.Init(options.Window, options.Renderer); err != nil {
-		panic(err)
+		return fmt.Errorf("boot: init application: %w", err)
 	}
 	defer app.Shutdown()
 
-	var quit int32
-	var sigChan = make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt)
-	go func() {
-		for sig := range sigChan {
-			if sig == os.Interrupt {
-				break
-			}
-		}
-		atomic.StoreInt32(&quit, 1)
-	}()
-
 	if options.Start != nil {
 		options.Start()
 	}
 
-	for !options.Window.ShouldClose() && atomic.LoadInt32(&quit) == 0 {
-		app.Update()
+	var loop = newLoop(options)
+	for !options.Window.ShouldClose() && ctx.Err() == nil {
+		loop.step(app)
 		options.Window.Update()
+		loop.wait()
 	}
+	return ctx.Err()
 }
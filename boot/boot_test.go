@@ -0,0 +1,124 @@
+package boot_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopherd/three/boot"
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/director"
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/driver/window"
+	"github.com/gopherd/three/geometry"
+	"github.com/gopherd/three/material"
+	"github.com/gopherd/three/object"
+)
+
+// countingApp counts calls of Application methods
+type countingApp struct {
+	window   window.Window
+	renderer renderer.Renderer
+	updates  int
+	shutdown bool
+}
+
+func (app *countingApp) Init(window window.Window, renderer renderer.Renderer) error {
+	app.window, app.renderer = window, renderer
+	return nil
+}
+
+func (app *countingApp) Update() { app.updates++ }
+
+func (app *countingApp) Shutdown() { app.shutdown = true }
+
+func TestRunHeadless(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		frames      int
+		cancel      bool
+		wantUpdates int
+	}{
+		{name: "frames", frames: 3, wantUpdates: 3},
+		{name: "canceled", cancel: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx, cancel = context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+			var app countingApp
+			var wantErr error
+			if tt.cancel {
+				wantErr = context.Canceled
+			}
+			if err := boot.Run(ctx, &app, boot.Options{
+				Width:    320,
+				Height:   240,
+				Window:   window.Headless(tt.frames),
+				Renderer: renderer.NullRenderer(),
+			}); err != wantErr {
+				t.Fatalf("got error %v, want %v", err, wantErr)
+			}
+			if app.updates != tt.wantUpdates || !app.shutdown {
+				t.Fatalf("got %d updates and shutdown %v, want %d updates and shutdown", app.updates, app.shutdown, tt.wantUpdates)
+			}
+			if width, height := app.window.GetFramebufferSize(); width != 320 || height != 240 {
+				t.Fatalf("got framebuffer size %dx%d, want 320x240", width, height)
+			}
+			img, err := app.renderer.ReadPixels(renderer.RenderTarget{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if size := img.Bounds().Size(); size.X != 320 || size.Y != 240 {
+				t.Fatalf("got image size %v, want 320x240", size)
+			}
+		})
+	}
+}
+
+func newTriangle() *object.Mesh {
+	var g = geometry.NewBufferGeometry()
+	var positions = geometry.NewFloat32Attribute(3, 3)
+	positions.SetXYZ(0, 0, 0, 0)
+	positions.SetXYZ(1, 1, 0, 0)
+	positions.SetXYZ(2, 0, 1, 0)
+	g.SetAttribute(geometry.AttributePosition, positions)
+	g.ComputeBounds()
+	return object.NewMesh(g, material.NewMeshBasicMaterial(material.MeshBasicMaterialParameters{}))
+}
+
+func TestRunDirectorHeadless(t *testing.T) {
+	const frames = 5
+	var scene = new(object.BasicScene)
+	var camera = object.NewPerspectiveCamera(45, 1, 0.1, 100)
+	camera.SetPosition(core.Vec3(0, 0, 5))
+	camera.LookAt(core.Vec3(0, 0, 0))
+	scene.SetCamera(camera)
+	scene.Add(camera)
+	var mesh = newTriangle()
+	scene.Add(mesh)
+
+	var updates int
+	if err := boot.Run(context.Background(), director.Application, boot.Options{
+		Window:   window.Headless(frames),
+		Renderer: renderer.NullRenderer(),
+		Start: func() {
+			director.RunScene(scene)
+			director.GetScheduler().ScheduleUpdate(nil, 0, func(time.Duration) { updates++ })
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if updates != frames {
+		t.Fatalf("got %d updates, want %d", updates, frames)
+	}
+	if err := mesh.ProgramError(); err != nil {
+		t.Fatal(err)
+	}
+	var stats = director.Stats()
+	if stats.DrawCalls != 1 || stats.Triangles != 1 || stats.Programs != 1 {
+		t.Fatalf("got %d draw calls, %d triangles and %d programs, want 1 of each", stats.DrawCalls, stats.Triangles, stats.Programs)
+	}
+}
@@ -0,0 +1,70 @@
+package boot
+
+import "time"
+
+// FixedApplication is an Application which supports fixed-timestep loop,
+// simulation is updated by FixedUpdate at a fixed rate while Render is
+// called once per frame
+type FixedApplication interface {
+	Application
+
+	// FixedUpdate updates simulation by the fixed timestep dt
+	FixedUpdate(dt time.Duration)
+	// Render renders a frame, alpha in [0, 1] is the interpolation factor
+	// between the previous and the current simulation states
+	Render(alpha float64)
+}
+
+// loop drives updating and rendering of application
+type loop struct {
+	timestep    time.Duration
+	maxSteps    int
+	frameTime   time.Duration // minimum duration of a frame, 0 if uncapped
+	startedAt   time.Time
+	updatedAt   time.Time
+	accumulator time.Duration
+}
+
+func newLoop(options Options) *loop {
+	var l = &loop{
+		timestep: options.FixedTimestep,
+		maxSteps: options.MaxUpdateSteps,
+	}
+	if options.MaxFPS > 0 {
+		l.frameTime = time.Second / time.Duration(options.MaxFPS)
+	}
+	l.updatedAt = time.Now()
+	return l
+}
+
+// step runs a frame of application
+func (l *loop) step(app Application) {
+	l.startedAt = time.Now()
+	fixed, ok := app.(FixedApplication)
+	if !ok || l.timestep <= 0 {
+		app.Update()
+		return
+	}
+	l.accumulator += l.startedAt.Sub(l.updatedAt)
+	l.updatedAt = l.startedAt
+	for steps := 0; l.accumulator >= l.timestep; steps++ {
+		if steps >= l.maxSteps {
+			// drop the time can't be caught up to avoid spiral of death
+			l.accumulator %= l.timestep
+			break
+		}
+		fixed.FixedUpdate(l.timestep)
+		l.accumulator -= l.timestep
+	}
+	fixed.Render(float64(l.accumulator) / float64(l.timestep))
+}
+
+// wait sleeps until the end of frame if frame rate is capped
+func (l *loop) wait() {
+	if l.frameTime <= 0 {
+		return
+	}
+	if d := l.frameTime - time.Since(l.startedAt); d > 0 {
+		time.Sleep(d)
+	}
+}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"os"
+	"os/signal"
 
 	"github.com/gopherd/three/boot"
 	"github.com/gopherd/three/core"
@@ -12,12 +16,16 @@ import (
 )
 
 func main() {
-	boot.Run(director.Application, boot.Options{
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := boot.Run(ctx, director.Application, boot.Options{
 		Title: "Demo",
 		Start: func() {
 			director.RunScene(NewScene())
 		},
-	})
+	}); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
 }
 
 type Scene struct {
@@ -68,9 +76,9 @@ func (scene *Scene) createMesh() *object.Mesh {
 }
 
 func (scene *Scene) OnEnter() {
-	log.Println("scene.OnEnter")
+	core.LogScene.Info("demo scene entered")
 }
 
 func (scene *Scene) OnExit() {
-	log.Println("scene.OnExit")
+	core.LogScene.Info("demo scene exited")
 }
@@ -0,0 +1,188 @@
+// Package controls implements camera controllers driven by input events of
+// the director
+package controls
+
+import (
+	"math"
+	"time"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/director"
+	"github.com/gopherd/three/input"
+	"github.com/gopherd/three/object"
+)
+
+// Camera is a camera whose transform can be driven by controls, both
+// PerspectiveCamera and OrthographicCamera implement it
+type Camera interface {
+	object.Camera
+
+	GetPosition() core.Vector3
+	SetPosition(core.Vector3)
+	GetQuaternion() core.Vector4
+	SetQuaternion(core.Vector4)
+	GetZoom() core.Float
+	SetZoom(core.Float)
+}
+
+// Controls is implemented by camera controllers
+type Controls interface {
+	// Camera returns the controlled camera
+	Camera() Camera
+	// Enabled reports whether the controls respond to input
+	Enabled() bool
+	// SetEnabled enables or disables the controls
+	SetEnabled(enabled bool)
+	// Update updates the camera by delta time, it's called by the
+	// director's scheduler with director.DeltaTime() every update
+	Update(dt time.Duration)
+	// Dispose detaches the controls from the director
+	Dispose()
+}
+
+// controls holds common state of controls
+type controls struct {
+	camera    Camera
+	disabled  bool
+	listeners []int
+	timer     director.TimerID
+	buttons   [input.MouseButtonLast + 1]bool
+	mods      input.ModifierKey // mods are modifiers when the latest button pressed
+	keys      map[input.Key]bool
+	handler   func(event.Event)
+}
+
+// attach listens input events of the director and schedules update
+func (c *controls) attach(camera Camera, handler func(event.Event), update func(dt time.Duration)) {
+	c.camera = camera
+	c.handler = handler
+	c.keys = make(map[input.Key]bool)
+	var dispatcher = director.Dispatcher()
+	c.listeners = append(c.listeners,
+		dispatcher.AddEventListener(event.Listen(input.KeyEventType, func(e input.KeyEvent) { c.handle(e) })),
+		dispatcher.AddEventListener(event.Listen(input.MouseButtonEventType, func(e input.MouseButtonEvent) { c.handle(e) })),
+		dispatcher.AddEventListener(event.Listen(input.MouseMoveEventType, func(e input.MouseMoveEvent) { c.handle(e) })),
+		dispatcher.AddEventListener(event.Listen(input.ScrollEventType, func(e input.ScrollEvent) { c.handle(e) })),
+	)
+	c.timer = director.GetScheduler().ScheduleUpdate(nil, 0, func(time.Duration) {
+		if !c.disabled {
+			update(director.DeltaTime())
+		}
+	})
+}
+
+// handle tracks pressed keys and buttons and forwards the event to handler
+func (c *controls) handle(e event.Event) {
+	if c.disabled {
+		return
+	}
+	switch e := e.(type) {
+	case input.KeyEvent:
+		switch e.Action {
+		case input.Press:
+			c.keys[e.Key] = true
+		case input.Release:
+			delete(c.keys, e.Key)
+		}
+	case input.MouseButtonEvent:
+		if e.Button < 0 || e.Button > input.MouseButtonLast {
+			return
+		}
+		c.buttons[e.Button] = e.Action == input.Press
+		if e.Action == input.Press {
+			c.mods = e.Mods
+		}
+	}
+	c.handler(e)
+}
+
+// Camera implements Controls Camera method
+func (c *controls) Camera() Camera {
+	return c.camera
+}
+
+// Enabled implements Controls Enabled method
+func (c *controls) Enabled() bool {
+	return !c.disabled
+}
+
+// SetEnabled implements Controls SetEnabled method
+func (c *controls) SetEnabled(enabled bool) {
+	c.disabled = !enabled
+	if c.disabled {
+		c.reset()
+	}
+}
+
+// Dispose implements Controls Dispose method, it must not be called by
+// input event listeners
+func (c *controls) Dispose() {
+	var dispatcher = director.Dispatcher()
+	for _, id := range c.listeners {
+		dispatcher.RemoveEventListener(id)
+	}
+	c.listeners = nil
+	director.GetScheduler().Unschedule(c.timer)
+	c.reset()
+}
+
+func (c *controls) reset() {
+	c.buttons = [input.MouseButtonLast + 1]bool{}
+	c.mods = 0
+	for key := range c.keys {
+		delete(c.keys, key)
+	}
+}
+
+// axis returns 1 if positive key is held, -1 if negative key is held or 0
+func (c *controls) axis(positive, negative input.Key) float64 {
+	var value float64
+	if c.keys[positive] {
+		value++
+	}
+	if c.keys[negative] {
+		value--
+	}
+	return value
+}
+
+// screenSize returns size of window in screen coordinates
+func screenSize() (width, height float64) {
+	var size = director.WindowSize()
+	return math.Max(1, float64(size.Width)), math.Max(1, float64(size.Height))
+}
+
+// damping returns the fraction of remaining motion applied in dt, motion
+// is applied at once if factor is 0
+func damping(factor float64, dt time.Duration) float64 {
+	if factor <= 0 {
+		return 1
+	}
+	return 1 - math.Pow(1-math.Min(factor, 1), dt.Seconds()*60)
+}
+
+// panOffset returns offset in world space of panning camera by (dx, dy) in
+// screen coordinates, distance is distance from camera to the pivot
+func panOffset(camera Camera, dx, dy float64, distance core.Float) core.Vector3 {
+	var width, height = screenSize()
+	var unitsX, unitsY float64
+	switch camera := camera.(type) {
+	case *object.OrthographicCamera:
+		var zoom = float64(camera.GetZoom())
+		unitsX = float64(camera.GetRight()-camera.GetLeft()) / zoom / width
+		unitsY = float64(camera.GetTop()-camera.GetBottom()) / zoom / height
+	case *object.PerspectiveCamera:
+		var halfFov = float64(camera.GetFov()) * math.Pi / 360
+		unitsX = 2 * float64(distance) * math.Tan(halfFov) / height
+		unitsY = unitsX
+	default:
+		unitsX = float64(distance) / height
+		unitsY = unitsX
+	}
+	var q = camera.GetQuaternion()
+	var right = core.QuaternionRotate(q, core.Vec3(1, 0, 0))
+	var up = core.QuaternionRotate(q, core.Vec3(0, 1, 0))
+	// dragging moves the scene with the pointer, so camera moves oppositely
+	return right.Mul(core.Float(-dx * unitsX)).Add(up.Mul(core.Float(dy * unitsY)))
+}
@@ -0,0 +1,114 @@
+package controls
+
+import (
+	"math"
+	"time"
+
+	"github.com/gopherd/doge/math/mathutil"
+	"github.com/gopherd/doge/math/tensor"
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/director"
+	"github.com/gopherd/three/input"
+)
+
+var _ Controls = (*FirstPersonControls)(nil)
+
+// FirstPersonControls turns the camera by mouse with the pointer locked
+// and walks by keys. Clicking locks the pointer and escape unlocks it. W/S
+// walks forward and backward, A/D strafes, space and control moves up and
+// down.
+type FirstPersonControls struct {
+	controls
+
+	MovementSpeed float64 // MovementSpeed is units per second, default 1
+	LookSpeed     float64 // LookSpeed is radians per screen coordinate, default 0.002
+	MaxPitch      float64 // MaxPitch limits looking up and down, default just under π/2
+
+	yaw, pitch float64
+	locked     bool
+}
+
+// NewFirstPersonControls creates FirstPersonControls of camera
+func NewFirstPersonControls(camera Camera) *FirstPersonControls {
+	var c = &FirstPersonControls{
+		MovementSpeed: 1,
+		LookSpeed:     0.002,
+		MaxPitch:      math.Pi/2 - 0.01,
+	}
+	var forward = core.QuaternionRotate(camera.GetQuaternion(), core.Vec3(0, 0, -1))
+	c.yaw = math.Atan2(float64(-forward.X()), float64(-forward.Z()))
+	c.pitch = math.Asin(mathutil.Clamp(float64(forward.Y()), -1, 1))
+	c.attach(camera, c.onEvent, c.Update)
+	return c
+}
+
+// Lock locks the pointer to look around
+func (c *FirstPersonControls) Lock() {
+	c.locked = true
+	director.SetPointerLock(true)
+}
+
+// Unlock unlocks the pointer
+func (c *FirstPersonControls) Unlock() {
+	if c.locked {
+		c.locked = false
+		director.SetPointerLock(false)
+	}
+}
+
+// IsLocked reports whether the pointer is locked by the controls
+func (c *FirstPersonControls) IsLocked() bool {
+	return c.locked && director.IsPointerLocked()
+}
+
+// SetEnabled implements Controls SetEnabled method, the pointer is unlocked
+// if disabled
+func (c *FirstPersonControls) SetEnabled(enabled bool) {
+	if !enabled {
+		c.Unlock()
+	}
+	c.controls.SetEnabled(enabled)
+}
+
+// Dispose implements Controls Dispose method
+func (c *FirstPersonControls) Dispose() {
+	c.Unlock()
+	c.controls.Dispose()
+}
+
+func (c *FirstPersonControls) onEvent(e event.Event) {
+	switch e := e.(type) {
+	case input.MouseButtonEvent:
+		if e.Button == input.MouseButtonLeft && e.Action == input.Press && !c.IsLocked() {
+			c.Lock()
+		}
+	case input.KeyEvent:
+		if e.Key == input.KeyEscape && e.Action == input.Press {
+			c.Unlock()
+		}
+	case input.MouseMoveEvent:
+		if c.IsLocked() {
+			c.yaw -= e.DX * c.LookSpeed
+			c.pitch = mathutil.Clamp(c.pitch-e.DY*c.LookSpeed, -c.MaxPitch, c.MaxPitch)
+		}
+	}
+}
+
+// Update implements Controls Update method
+func (c *FirstPersonControls) Update(dt time.Duration) {
+	var sin, cos = math.Sincos(c.yaw)
+	var forward = core.Vec3(core.Float(-sin), 0, core.Float(-cos))
+	var right = core.Vec3(core.Float(cos), 0, core.Float(-sin))
+	var move = forward.Mul(core.Float(c.axis(input.KeyW, input.KeyS))).
+		Add(right.Mul(core.Float(c.axis(input.KeyD, input.KeyA)))).
+		Add(core.Vec3(0, core.Float(c.axis(input.KeySpace, input.KeyLeftControl)), 0))
+	if move.Square() > 0 {
+		var offset = move.Normalize().Mul(core.Float(c.MovementSpeed * dt.Seconds()))
+		c.camera.SetPosition(c.camera.GetPosition().Add(offset))
+	}
+	c.camera.SetQuaternion(core.QuaternionFromEuler(core.Euler{
+		Vector3: core.Vec3(core.Float(c.pitch), core.Float(c.yaw), 0),
+		Order:   tensor.EulerRotationOrderYXZ,
+	}))
+}
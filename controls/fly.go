@@ -0,0 +1,74 @@
+package controls
+
+import (
+	"time"
+
+	"github.com/gopherd/doge/math/tensor"
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/input"
+)
+
+var _ Controls = (*FlyControls)(nil)
+
+// FlyControls moves and rotates the camera freely in its local space. W/S
+// moves forward and backward, A/D moves left and right, R/F moves up and
+// down, Q/E rolls, arrow keys or dragging with the left button turns.
+type FlyControls struct {
+	controls
+
+	MovementSpeed float64 // MovementSpeed is units per second, default 1
+	RollSpeed     float64 // RollSpeed is radians per second of rolling and turning by keys, default 1
+	LookSpeed     float64 // LookSpeed is radians per screen coordinate of turning by dragging, default 0.005
+
+	look struct {
+		dx, dy float64 // dx, dy are accumulated dragging in screen coordinates
+	}
+}
+
+// NewFlyControls creates FlyControls of camera
+func NewFlyControls(camera Camera) *FlyControls {
+	var c = &FlyControls{
+		MovementSpeed: 1,
+		RollSpeed:     1,
+		LookSpeed:     0.005,
+	}
+	c.attach(camera, c.onEvent, c.Update)
+	return c
+}
+
+func (c *FlyControls) onEvent(e event.Event) {
+	if e, ok := e.(input.MouseMoveEvent); ok && c.buttons[input.MouseButtonLeft] {
+		c.look.dx += e.DX
+		c.look.dy += e.DY
+	}
+}
+
+// Update implements Controls Update method
+func (c *FlyControls) Update(dt time.Duration) {
+	var seconds = dt.Seconds()
+	var q = c.camera.GetQuaternion()
+
+	var move = core.Vec3(
+		core.Float(c.axis(input.KeyD, input.KeyA)),
+		core.Float(c.axis(input.KeyR, input.KeyF)),
+		core.Float(c.axis(input.KeyS, input.KeyW)),
+	)
+	if move.Square() > 0 {
+		var offset = core.QuaternionRotate(q, move.Normalize()).Mul(core.Float(c.MovementSpeed * seconds))
+		c.camera.SetPosition(c.camera.GetPosition().Add(offset))
+	}
+
+	var pitch = c.axis(input.KeyUp, input.KeyDown)*c.RollSpeed*seconds - c.look.dy*c.LookSpeed
+	var yaw = c.axis(input.KeyLeft, input.KeyRight)*c.RollSpeed*seconds - c.look.dx*c.LookSpeed
+	var roll = c.axis(input.KeyQ, input.KeyE) * c.RollSpeed * seconds
+	c.look.dx, c.look.dy = 0, 0
+	if pitch != 0 || yaw != 0 || roll != 0 {
+		var rotation = core.QuaternionFromEuler(core.Euler{
+			Vector3: core.Vec3(core.Float(pitch), core.Float(yaw), core.Float(roll)),
+			Order:   tensor.EulerRotationOrderXYZ,
+		})
+		// rotates in local space of camera
+		c.camera.SetQuaternion(core.QuaternionMul(q, rotation).Normalize())
+	}
+}
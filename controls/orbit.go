@@ -0,0 +1,157 @@
+package controls
+
+import (
+	"math"
+	"time"
+
+	"github.com/gopherd/doge/math/mathutil"
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/input"
+	"github.com/gopherd/three/object"
+)
+
+// zoomStep is the scale of distance per scroll step if ZoomSpeed is 1
+const zoomStep = 0.95
+
+// minPolarOffset keeps polar angle away from poles to avoid flipping
+const minPolarOffset = 1e-6
+
+var _ Controls = (*OrbitControls)(nil)
+
+// OrbitControls orbits the camera around a target with Y axis up. Dragging
+// with the left button rotates, dragging with the right button or the left
+// button with shift or control pans, dragging with the middle button or
+// scrolling dollies. Orthographic cameras zoom instead of dollying.
+type OrbitControls struct {
+	controls
+
+	Target core.Vector3 // Target is the point the camera orbits around
+
+	RotateSpeed     float64 // RotateSpeed scales rotating, default 1
+	ZoomSpeed       float64 // ZoomSpeed scales dollying and zooming, default 1
+	PanSpeed        float64 // PanSpeed scales panning, default 1
+	AutoRotateSpeed float64 // AutoRotateSpeed is radians per second rotating around target while idle
+	// DampingFactor is the fraction of remaining motion applied per 1/60
+	// second, 0 disables damping
+	DampingFactor float64
+
+	MinDistance, MaxDistance         float64 // limits of distance for perspective cameras
+	MinZoom, MaxZoom                 float64 // limits of zoom for orthographic cameras
+	MinPolarAngle, MaxPolarAngle     float64 // limits of vertical angle from Y axis in [0, π]
+	MinAzimuthAngle, MaxAzimuthAngle float64 // limits of horizontal angle around Y axis
+
+	DisableRotate bool
+	DisableZoom   bool
+	DisablePan    bool
+
+	delta struct {
+		theta, phi float64
+		logScale   float64 // logScale is logarithm of scale of distance
+		pan        core.Vector3
+	}
+}
+
+// NewOrbitControls creates OrbitControls of camera orbiting around target
+func NewOrbitControls(camera Camera, target core.Vector3) *OrbitControls {
+	var c = &OrbitControls{
+		Target:          target,
+		RotateSpeed:     1,
+		ZoomSpeed:       1,
+		PanSpeed:        1,
+		MaxDistance:     math.Inf(1),
+		MaxZoom:         math.Inf(1),
+		MaxPolarAngle:   math.Pi,
+		MinAzimuthAngle: math.Inf(-1),
+		MaxAzimuthAngle: math.Inf(1),
+	}
+	c.attach(camera, c.onEvent, c.Update)
+	c.Update(0)
+	return c
+}
+
+func (c *OrbitControls) onEvent(e event.Event) {
+	switch e := e.(type) {
+	case input.MouseMoveEvent:
+		var _, height = screenSize()
+		switch {
+		case c.buttons[input.MouseButtonLeft] && c.mods&(input.ModShift|input.ModControl) == 0:
+			if !c.DisableRotate {
+				c.delta.theta -= 2 * math.Pi * e.DX / height * c.RotateSpeed
+				c.delta.phi -= 2 * math.Pi * e.DY / height * c.RotateSpeed
+			}
+		case c.buttons[input.MouseButtonLeft], c.buttons[input.MouseButtonRight]:
+			if !c.DisablePan {
+				var distance = c.camera.GetPosition().Sub(c.Target).Length()
+				var offset = panOffset(c.camera, e.DX*c.PanSpeed, e.DY*c.PanSpeed, distance)
+				c.delta.pan = c.delta.pan.Add(offset)
+			}
+		case c.buttons[input.MouseButtonMiddle]:
+			if !c.DisableZoom {
+				c.delta.logScale -= math.Log(zoomStep) * e.DY / 10 * c.ZoomSpeed
+			}
+		}
+	case input.ScrollEvent:
+		if !c.DisableZoom {
+			// scrolling up dollies in
+			c.delta.logScale += math.Log(zoomStep) * e.DY * c.ZoomSpeed
+		}
+	}
+}
+
+// Update implements Controls Update method
+func (c *OrbitControls) Update(dt time.Duration) {
+	var f = damping(c.DampingFactor, dt)
+	var offset = c.camera.GetPosition().Sub(c.Target)
+	var radius = float64(offset.Length())
+	var theta, phi float64
+	if radius > 0 {
+		theta = math.Atan2(float64(offset.X()), float64(offset.Z()))
+		phi = math.Acos(mathutil.Clamp(float64(offset.Y())/radius, -1, 1))
+	} else {
+		phi = math.Pi / 2
+	}
+
+	if c.AutoRotateSpeed != 0 && !c.dragging() {
+		theta -= c.AutoRotateSpeed * dt.Seconds()
+	}
+	theta += c.delta.theta * f
+	phi += c.delta.phi * f
+	if !math.IsInf(c.MinAzimuthAngle, -1) || !math.IsInf(c.MaxAzimuthAngle, 1) {
+		theta = mathutil.Clamp(theta, c.MinAzimuthAngle, c.MaxAzimuthAngle)
+	}
+	phi = mathutil.Clamp(phi, c.MinPolarAngle, c.MaxPolarAngle)
+	phi = mathutil.Clamp(phi, minPolarOffset, math.Pi-minPolarOffset)
+
+	var scale = math.Exp(c.delta.logScale * f)
+	if camera, ok := c.camera.(*object.OrthographicCamera); ok {
+		camera.SetZoom(core.Float(mathutil.Clamp(float64(camera.GetZoom())/scale, c.MinZoom, c.MaxZoom)))
+	} else {
+		radius = mathutil.Clamp(radius*scale, c.MinDistance, c.MaxDistance)
+	}
+	c.Target = c.Target.Add(c.delta.pan.Mul(core.Float(f)))
+
+	var sinPhi = math.Sin(phi)
+	offset = core.Vec3(
+		core.Float(radius*sinPhi*math.Sin(theta)),
+		core.Float(radius*math.Cos(phi)),
+		core.Float(radius*sinPhi*math.Cos(theta)),
+	)
+	var position = c.Target.Add(offset)
+	c.camera.SetPosition(position)
+	c.camera.SetQuaternion(core.QuaternionLookAt(position, c.Target, core.Vec3(0, 1, 0)))
+
+	c.delta.theta *= 1 - f
+	c.delta.phi *= 1 - f
+	c.delta.logScale *= 1 - f
+	c.delta.pan = c.delta.pan.Mul(core.Float(1 - f))
+}
+
+func (c *OrbitControls) dragging() bool {
+	for _, pressed := range c.buttons {
+		if pressed {
+			return true
+		}
+	}
+	return false
+}
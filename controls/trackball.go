@@ -0,0 +1,135 @@
+package controls
+
+import (
+	"math"
+	"time"
+
+	"github.com/gopherd/doge/math/mathutil"
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/input"
+	"github.com/gopherd/three/object"
+)
+
+var _ Controls = (*TrackballControls)(nil)
+
+// TrackballControls rotates the camera around a target freely without a
+// fixed up axis. Dragging with the left button rotates, dragging with the
+// right button pans, dragging with the middle button or scrolling zooms.
+type TrackballControls struct {
+	controls
+
+	Target core.Vector3 // Target is the point the camera rotates around
+
+	RotateSpeed float64 // RotateSpeed scales rotating, default 1
+	ZoomSpeed   float64 // ZoomSpeed scales zooming, default 1
+	PanSpeed    float64 // PanSpeed scales panning, default 1
+	// DampingFactor is the fraction of remaining motion applied per 1/60
+	// second, 0 disables damping
+	DampingFactor float64
+
+	MinDistance, MaxDistance float64 // limits of distance for perspective cameras
+	MinZoom, MaxZoom         float64 // limits of zoom for orthographic cameras
+
+	DisableRotate bool
+	DisableZoom   bool
+	DisablePan    bool
+
+	up    core.Vector3 // up is up direction of camera
+	delta struct {
+		rotateX, rotateY float64 // rotating in screen coordinates
+		panX, panY       float64 // panning in screen coordinates
+		logScale         float64 // logScale is logarithm of scale of distance
+	}
+}
+
+// NewTrackballControls creates TrackballControls of camera rotating around
+// target
+func NewTrackballControls(camera Camera, target core.Vector3) *TrackballControls {
+	var c = &TrackballControls{
+		Target:      target,
+		RotateSpeed: 1,
+		ZoomSpeed:   1,
+		PanSpeed:    1,
+		MaxDistance: math.Inf(1),
+		MaxZoom:     math.Inf(1),
+		up:          core.QuaternionRotate(camera.GetQuaternion(), core.Vec3(0, 1, 0)),
+	}
+	c.attach(camera, c.onEvent, c.Update)
+	c.Update(0)
+	return c
+}
+
+func (c *TrackballControls) onEvent(e event.Event) {
+	switch e := e.(type) {
+	case input.MouseMoveEvent:
+		switch {
+		case c.buttons[input.MouseButtonLeft]:
+			if !c.DisableRotate {
+				c.delta.rotateX += e.DX
+				c.delta.rotateY += e.DY
+			}
+		case c.buttons[input.MouseButtonRight]:
+			if !c.DisablePan {
+				c.delta.panX += e.DX
+				c.delta.panY += e.DY
+			}
+		case c.buttons[input.MouseButtonMiddle]:
+			if !c.DisableZoom {
+				c.delta.logScale -= math.Log(zoomStep) * e.DY / 10 * c.ZoomSpeed
+			}
+		}
+	case input.ScrollEvent:
+		if !c.DisableZoom {
+			c.delta.logScale += math.Log(zoomStep) * e.DY * c.ZoomSpeed
+		}
+	}
+}
+
+// Update implements Controls Update method
+func (c *TrackballControls) Update(dt time.Duration) {
+	var f = damping(c.DampingFactor, dt)
+	var eye = c.camera.GetPosition().Sub(c.Target)
+
+	var rx, ry = c.delta.rotateX * f, c.delta.rotateY * f
+	if (rx != 0 || ry != 0) && eye.Square() > 0 {
+		var _, height = screenSize()
+		var angle = math.Hypot(rx, ry) / height * math.Pi * c.RotateSpeed
+		var eyeDirection = eye.Normalize()
+		var upDirection = c.up.Normalize()
+		var sideways = upDirection.Cross(eyeDirection).Normalize()
+		// moving the pointer up moves the camera down around target
+		var move = upDirection.Mul(core.Float(-ry)).Add(sideways.Mul(core.Float(rx)))
+		var axis = move.Cross(eye)
+		if axis.Square() > 0 {
+			var q = core.QuaternionFromAxisAngle(axis.Normalize(), core.Float(angle))
+			eye = core.QuaternionRotate(q, eye)
+			c.up = core.QuaternionRotate(q, c.up)
+		}
+	}
+
+	var scale = math.Exp(c.delta.logScale * f)
+	if camera, ok := c.camera.(*object.OrthographicCamera); ok {
+		camera.SetZoom(core.Float(mathutil.Clamp(float64(camera.GetZoom())/scale, c.MinZoom, c.MaxZoom)))
+	} else if length := float64(eye.Length()); length > 0 {
+		var distance = mathutil.Clamp(length*scale, c.MinDistance, c.MaxDistance)
+		eye = eye.Mul(core.Float(distance / length))
+	}
+
+	if px, py := c.delta.panX*f, c.delta.panY*f; px != 0 || py != 0 {
+		var offset = panOffset(c.camera, px*c.PanSpeed, py*c.PanSpeed, eye.Length())
+		c.Target = c.Target.Add(offset)
+	}
+
+	var position = c.Target.Add(eye)
+	c.camera.SetPosition(position)
+	c.camera.SetQuaternion(core.QuaternionLookAt(position, c.Target, c.up))
+	// keeps up orthogonal to eye direction
+	c.up = core.QuaternionRotate(c.camera.GetQuaternion(), core.Vec3(0, 1, 0))
+
+	c.delta.rotateX *= 1 - f
+	c.delta.rotateY *= 1 - f
+	c.delta.panX *= 1 - f
+	c.delta.panY *= 1 - f
+	c.delta.logScale *= 1 - f
+}
@@ -11,14 +11,27 @@ type Float = float32
 type Vector2 = tensor.Vector2[Float]
 type Vector3 = tensor.Vector3[Float]
 type Vector4 = tensor.Vector4[Float]
+type Matrix3 = tensor.Matrix3[Float]
 type Matrix4 = tensor.Matrix4[Float]
 type Euler = tensor.Euler[Float]
 
 func Vec2(x, y Float) Vector2       { return tensor.Vec2(x, y) }
 func Vec3(x, y, z Float) Vector3    { return tensor.Vec3(x, y, z) }
 func Vec4(x, y, z, w Float) Vector4 { return tensor.Vec4(x, y, z, w) }
+func One3x3() Matrix3               { return tensor.One3x3[Float]() }
 func One4x4() Matrix4               { return tensor.One4x4[Float]() }
 
+// NormalMatrix returns the inverse transpose of upper-left 3x3 matrix of m
+func NormalMatrix(m Matrix4) Matrix3 {
+	var n Matrix3
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			n[i+j*3] = m[i+j*4]
+		}
+	}
+	return n.Invert().Transpose()
+}
+
 func Color(c color.Color) Vector4 {
 	const max = 0xffff
 	var r, g, b, a = c.RGBA()
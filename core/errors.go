@@ -0,0 +1,84 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PanicError is reported when updating or rendering panics, the frame is
+// skipped and the application keeps running
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error implements error Error method, the stack is excluded so repeated
+// panics are de-duplicated
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// errorInterval is the interval errors with the same message are reported
+// at most once
+const errorInterval = 10 * time.Second
+
+var errorHandler struct {
+	sync.Mutex
+	handler  func(error)
+	reported map[string]time.Time
+}
+
+// SetErrorHandler sets the handler of errors of updating and rendering,
+// e.g. to fall back, retry or cancel the context passed to boot.Run. nil
+// restores the default handler which logs errors by the logger.
+func SetErrorHandler(handler func(error)) {
+	errorHandler.Lock()
+	defer errorHandler.Unlock()
+	errorHandler.handler = handler
+}
+
+// ReportError reports the error to the error handler, errors with the same
+// message are reported at most once per 10 seconds to avoid spamming by
+// errors of every frame
+func ReportError(err error) {
+	if err == nil {
+		return
+	}
+	var now = time.Now()
+	var message = err.Error()
+	errorHandler.Lock()
+	if at, ok := errorHandler.reported[message]; ok && now.Sub(at) < errorInterval {
+		errorHandler.Unlock()
+		return
+	}
+	if errorHandler.reported == nil {
+		errorHandler.reported = make(map[string]time.Time)
+	}
+	if len(errorHandler.reported) >= 256 {
+		for message, at := range errorHandler.reported {
+			if now.Sub(at) >= errorInterval {
+				delete(errorHandler.reported, message)
+			}
+		}
+	}
+	errorHandler.reported[message] = now
+	var handler = errorHandler.handler
+	errorHandler.Unlock()
+
+	if handler != nil {
+		handler(err)
+	} else {
+		logError(err)
+	}
+}
+
+func logError(err error) {
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		GetLogger().Error(err.Error(), "stack", "\n"+string(panicErr.Stack))
+	} else {
+		GetLogger().Error(err.Error())
+	}
+}
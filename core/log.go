@@ -0,0 +1,169 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger logs diagnostics of the engine, args are alternating keys and
+// values. It's method set of *slog.Logger, so a slog logger can be used
+// directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// LogLevel is severity of logs, values are the same as slog levels
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = -4
+	LevelInfo  LogLevel = 0
+	LevelWarn  LogLevel = 4
+	LevelError LogLevel = 8
+)
+
+// String implements fmt.Stringer String method
+func (level LogLevel) String() string {
+	switch {
+	case level < LevelInfo:
+		return "DEBUG"
+	case level < LevelWarn:
+		return "INFO"
+	case level < LevelError:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// LogCategory is the subsystem logs come from, it's logged as the
+// "category" attribute so handlers can filter by it
+type LogCategory string
+
+const (
+	LogWindow   LogCategory = "window"
+	LogRenderer LogCategory = "renderer"
+	LogShader   LogCategory = "shader"
+	LogResource LogCategory = "resource"
+	LogScene    LogCategory = "scene"
+	LogInput    LogCategory = "input"
+)
+
+// Debug logs msg at LevelDebug
+func (category LogCategory) Debug(msg string, args ...any) {
+	GetLogger().Debug(msg, category.args(args)...)
+}
+
+// Info logs msg at LevelInfo
+func (category LogCategory) Info(msg string, args ...any) {
+	GetLogger().Info(msg, category.args(args)...)
+}
+
+// Warn logs msg at LevelWarn
+func (category LogCategory) Warn(msg string, args ...any) {
+	GetLogger().Warn(msg, category.args(args)...)
+}
+
+// Error logs msg at LevelError
+func (category LogCategory) Error(msg string, args ...any) {
+	GetLogger().Error(msg, category.args(args)...)
+}
+
+// Log logs msg at the level
+func (category LogCategory) Log(level LogLevel, msg string, args ...any) {
+	switch {
+	case level < LevelInfo:
+		category.Debug(msg, args...)
+	case level < LevelWarn:
+		category.Info(msg, args...)
+	case level < LevelError:
+		category.Warn(msg, args...)
+	default:
+		category.Error(msg, args...)
+	}
+}
+
+func (category LogCategory) args(args []any) []any {
+	return append([]any{"category", string(category)}, args...)
+}
+
+var logger struct {
+	sync.RWMutex
+	logger Logger
+}
+
+var defaultLogger = NewLogger(os.Stderr, LevelInfo)
+
+// SetLogger sets the logger of the engine, nil restores the default logger
+// which writes logs at LevelInfo or higher to stderr
+func SetLogger(l Logger) {
+	logger.Lock()
+	defer logger.Unlock()
+	logger.logger = l
+}
+
+// GetLogger returns the logger of the engine
+func GetLogger() Logger {
+	logger.RLock()
+	defer logger.RUnlock()
+	if logger.logger == nil {
+		return defaultLogger
+	}
+	return logger.logger
+}
+
+// textLogger implements Logger, it writes a line per log like
+//
+//	2006/01/02 15:04:05 WARN msg category=shader key=value
+type textLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level LogLevel
+}
+
+// NewLogger creates a Logger which writes logs at level or higher to w as
+// text, e.g. for applications without slog
+func NewLogger(w io.Writer, level LogLevel) Logger {
+	return &textLogger{w: w, level: level}
+}
+
+// Debug implements Logger Debug method
+func (l *textLogger) Debug(msg string, args ...any) { l.log(LevelDebug, msg, args) }
+
+// Info implements Logger Info method
+func (l *textLogger) Info(msg string, args ...any) { l.log(LevelInfo, msg, args) }
+
+// Warn implements Logger Warn method
+func (l *textLogger) Warn(msg string, args ...any) { l.log(LevelWarn, msg, args) }
+
+// Error implements Logger Error method
+func (l *textLogger) Error(msg string, args ...any) { l.log(LevelError, msg, args) }
+
+func (l *textLogger) log(level LogLevel, msg string, args []any) {
+	if level < l.level {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(time.Now().Format("2006/01/02 15:04:05 "))
+	b.WriteString(level.String())
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+		} else {
+			fmt.Fprintf(&b, " !BADKEY=%v", args[i])
+		}
+	}
+	b.WriteByte('\n')
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.w, b.String())
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"math"
+
+	"github.com/gopherd/doge/math/tensor"
+)
+
+// Quaternions are represented by Vector4 as (x, y, z, w)
+
+// IdentityQuaternion returns the quaternion without rotation
+func IdentityQuaternion() Vector4 { return Vec4(0, 0, 0, 1) }
+
+// QuaternionFromAxisAngle returns the quaternion rotating angle radians
+// around the normalized axis
+func QuaternionFromAxisAngle(axis Vector3, angle Float) Vector4 {
+	var s, c = math.Sincos(float64(angle) / 2)
+	var v = axis.Mul(Float(s))
+	return Vec4(v.X(), v.Y(), v.Z(), Float(c))
+}
+
+// QuaternionFromEuler returns the quaternion of euler angles
+func QuaternionFromEuler(euler Euler) Vector4 {
+	var s1, c1 = math.Sincos(float64(euler.X()) / 2)
+	var s2, c2 = math.Sincos(float64(euler.Y()) / 2)
+	var s3, c3 = math.Sincos(float64(euler.Z()) / 2)
+	var x, y, z, w float64
+	switch euler.Order {
+	case tensor.EulerRotationOrderYXZ:
+		x = s1*c2*c3 + c1*s2*s3
+		y = c1*s2*c3 - s1*c2*s3
+		z = c1*c2*s3 - s1*s2*c3
+		w = c1*c2*c3 + s1*s2*s3
+	case tensor.EulerRotationOrderZXY:
+		x = s1*c2*c3 - c1*s2*s3
+		y = c1*s2*c3 + s1*c2*s3
+		z = c1*c2*s3 + s1*s2*c3
+		w = c1*c2*c3 - s1*s2*s3
+	case tensor.EulerRotationOrderZYX:
+		x = s1*c2*c3 - c1*s2*s3
+		y = c1*s2*c3 + s1*c2*s3
+		z = c1*c2*s3 - s1*s2*c3
+		w = c1*c2*c3 + s1*s2*s3
+	case tensor.EulerRotationOrderYZX:
+		x = s1*c2*c3 + c1*s2*s3
+		y = c1*s2*c3 + s1*c2*s3
+		z = c1*c2*s3 - s1*s2*c3
+		w = c1*c2*c3 - s1*s2*s3
+	case tensor.EulerRotationOrderXZY:
+		x = s1*c2*c3 - c1*s2*s3
+		y = c1*s2*c3 - s1*c2*s3
+		z = c1*c2*s3 + s1*s2*c3
+		w = c1*c2*c3 + s1*s2*s3
+	default: // XYZ
+		x = s1*c2*c3 + c1*s2*s3
+		y = c1*s2*c3 - s1*c2*s3
+		z = c1*c2*s3 + s1*s2*c3
+		w = c1*c2*c3 - s1*s2*s3
+	}
+	return Vec4(Float(x), Float(y), Float(z), Float(w))
+}
+
+// QuaternionLookAt returns the rotation whose -Z axis points from eye to
+// target and whose Y axis is close to up
+func QuaternionLookAt(eye, target, up Vector3) Vector4 {
+	var z = eye.Sub(target)
+	if z.Square() == 0 {
+		z = Vec3(0, 0, 1)
+	}
+	z = z.Normalize()
+	var x = up.Cross(z)
+	if x.Square() == 0 {
+		// up and z are parallel, nudge z
+		if math.Abs(float64(up.Z())) == 1 {
+			z = Vec3(z.X()+0.0001, z.Y(), z.Z()).Normalize()
+		} else {
+			z = Vec3(z.X(), z.Y(), z.Z()+0.0001).Normalize()
+		}
+		x = up.Cross(z)
+	}
+	x = x.Normalize()
+	var y = z.Cross(x)
+	var m = One4x4()
+	m[0], m[1], m[2] = x.X(), x.Y(), x.Z()
+	m[4], m[5], m[6] = y.X(), y.Y(), y.Z()
+	m[8], m[9], m[10] = z.X(), z.Y(), z.Z()
+	return m.GetQuaternion()
+}
+
+// QuaternionMul returns the quaternion rotating by b and then by a
+func QuaternionMul(a, b Vector4) Vector4 {
+	var ax, ay, az, aw = a.X(), a.Y(), a.Z(), a.W()
+	var bx, by, bz, bw = b.X(), b.Y(), b.Z(), b.W()
+	return Vec4(
+		ax*bw+aw*bx+ay*bz-az*by,
+		ay*bw+aw*by+az*bx-ax*bz,
+		az*bw+aw*bz+ax*by-ay*bx,
+		aw*bw-ax*bx-ay*by-az*bz,
+	)
+}
+
+// QuaternionRotate rotates the vector by the quaternion
+func QuaternionRotate(q Vector4, v Vector3) Vector3 {
+	var u = Vec3(q.X(), q.Y(), q.Z())
+	var w = q.W()
+	// v + 2w(u × v) + 2u × (u × v)
+	var t = u.Cross(v).Mul(2)
+	return v.Add(t.Mul(w)).Add(u.Cross(t))
+}
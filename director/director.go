@@ -5,26 +5,57 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/gopherd/doge/operator"
 	"github.com/gopherd/three/boot"
+	"github.com/gopherd/three/core"
 	"github.com/gopherd/three/core/event"
 	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/driver/renderer/shader"
 	"github.com/gopherd/three/driver/window"
+	"github.com/gopherd/three/input"
 	"github.com/gopherd/three/object"
 )
 
 var director struct {
-	dispatcher event.Dispatcher
-	window     window.Window
-	renderer   renderer.Renderer
+	dispatcher   event.Dispatcher
+	input        input.State
+	gamepads     input.GamepadSource
+	actions      []*input.ActionContext
+	sceneActions map[object.Scene]*input.ActionContext // sceneActions holds contexts set by SetSceneActions
+	window       window.Window
+	renderer     renderer.Renderer
 
-	scenes []object.Scene
-	camera object.Camera
+	scenes     []object.Scene
+	camera     object.Camera
+	passes     []Pass
+	scheduler  *Scheduler
+	transition transitionState
+	resize     struct {
+		policy ResizePolicy
+		event  window.ResizeEvent // event is the latest ResizeEvent
+	}
 
 	updatedAt time.Time
 	deltaTime time.Duration
+	alpha     float64
+	frame     FrameStats // frame holds stats of current frame
+	stats     FrameStats // stats holds stats of the last frame
 }
 
-var Application boot.Application = application{}
+// FrameStats holds stats of a frame
+type FrameStats struct {
+	renderer.Stats
+	object.RenderStats
+
+	Update time.Duration // Update is CPU time of updating the scene
+	Frame  time.Duration // Frame is CPU time of the whole frame
+}
+
+func init() {
+	director.scheduler = NewScheduler()
+}
+
+var Application boot.FixedApplication = application{}
 
 type application struct{}
 
@@ -32,52 +63,126 @@ type application struct{}
 func (application) Init(window window.Window, renderer renderer.Renderer) error {
 	director.window = window
 	director.renderer = renderer
+	window.SetEventHandler(handleEvent)
+	if director.gamepads == nil {
+		director.gamepads = window.GamepadSource()
+	}
 	director.updatedAt = time.Now()
 	return nil
 }
 
 // Shutdown implements boot.Application Shutdown method
 func (application) Shutdown() {
-	if n := len(director.scenes); n > 0 {
-		director.scenes[n-1].OnExit()
-		director.scenes = director.scenes[:0]
-	}
+	finishTransition()
+	exitScenes(0)
 }
 
 // Update implements boot.Application Update method
 func (application) Update() {
-	defer func() {
-		if e := recover(); e != nil {
-			println(fmt.Sprintf("Error: %v\nStack:\n%v", e, string(debug.Stack())))
-		}
-	}()
+	defer recoverPanic()
 	var now = time.Now()
 	director.deltaTime = now.Sub(director.updatedAt)
 	director.updatedAt = now
+	director.alpha = 1
+	update()
+	render()
+}
+
+// FixedUpdate implements boot.FixedApplication FixedUpdate method
+func (application) FixedUpdate(dt time.Duration) {
+	defer recoverPanic()
+	director.deltaTime = dt
+	director.updatedAt = time.Now()
+	update()
+}
+
+// Render implements boot.FixedApplication Render method
+func (application) Render(alpha float64) {
+	defer recoverPanic()
+	director.alpha = alpha
+	render()
+}
 
+func recoverPanic() {
+	if e := recover(); e != nil {
+		core.ReportError(&core.PanicError{Value: e, Stack: debug.Stack()})
+	}
+}
+
+// update updates the running scene, time of updates is accumulated to
+// stats of current frame
+func update() {
+	commitInput()
+	var start = time.Now()
+	director.scheduler.update(director.deltaTime)
+	updateTransition(director.deltaTime)
+	if scene := GetRunningScene(); scene != nil {
+		object.Update(scene)
+	}
+	director.frame.Update += time.Since(start)
+}
+
+// render renders the running scene and finishes stats of current frame
+func render() {
+	var start = time.Now()
+	shader.Poll()
 	var scene = GetRunningScene()
 	if scene == nil {
 		return
 	}
-	object.Update(scene)
-	if director.camera != nil {
-		scene.Render(director.renderer, director.camera)
+	var stats = director.frame
+	director.frame = FrameStats{}
+	director.renderer.BeginFrame()
+	if director.transition.active() {
+		renderTransition()
+		stats.RenderStats = scene.RenderStats()
+	} else {
+		stats.RenderStats = renderPasses(scene)
 	}
+	director.renderer.EndFrame()
+	stats.Stats = director.renderer.Stats()
+	stats.Frame = stats.Update + time.Since(start)
+	director.stats = stats
+}
+
+// Stats returns stats of the last frame
+func Stats() FrameStats {
+	return director.stats
+}
+
+// SetGPUTimer enables or disables measuring GPU time of frames
+func SetGPUTimer(enabled bool) {
+	director.renderer.SetGPUTimer(enabled)
 }
 
-// DeltaTime returns delta time duration from last update
+// DeltaTime returns delta time duration from last update, it's the fixed
+// timestep if fixed-timestep loop enabled
 func DeltaTime() time.Duration {
 	return director.deltaTime
 }
 
+// Alpha returns interpolation factor between the previous and the current
+// states of fixed updates while rendering, it's always 1 if fixed-timestep
+// loop disabled
+func Alpha() float64 {
+	return director.alpha
+}
+
+// GetScheduler returns the scheduler driven by director
+func GetScheduler() *Scheduler {
+	return director.scheduler
+}
+
 // GetCamera returns current camera
 func GetCamera() object.Camera {
 	return director.camera
 }
 
-// SetCamera sets current camera
+// SetCamera sets current camera, the camera follows size of window if
+// resize policy is set
 func SetCamera(camera object.Camera) {
 	director.camera = camera
+	fitCamera(camera)
 }
 
 // GetRunningScene return the running scene
@@ -88,31 +193,306 @@ func GetRunningScene() object.Scene {
 	return nil
 }
 
-// RunScene runs the scene
+// handleEvent accumulates input events to polled input state and
+// dispatches them by the director's dispatcher
+func handleEvent(e event.Event) {
+	if resize, ok := e.(window.ResizeEvent); ok {
+		director.resize.event = resize
+		fitCamera(director.camera)
+		fitCamera(sceneCamera(GetRunningScene()))
+	}
+	director.input.Handle(e)
+	director.dispatcher.DispatchEvent(e)
+	handlePointer(e)
+}
+
+// ResizePolicy specifies how cameras follow size of window
+type ResizePolicy int
+
+const (
+	ResizeNone   ResizePolicy = iota // ResizeNone keeps cameras unchanged
+	ResizeAspect                     // ResizeAspect matches aspect of active camera to window
+)
+
+// SetResizePolicy sets how the active camera follows size of window, the
+// policy is applied immediately
+func SetResizePolicy(policy ResizePolicy) {
+	director.resize.policy = policy
+	fitCamera(director.camera)
+	fitCamera(sceneCamera(GetRunningScene()))
+}
+
+// WindowSize returns the latest size of window
+func WindowSize() window.ResizeEvent {
+	return director.resize.event
+}
+
+// fitCamera applies resize policy to the camera
+func fitCamera(camera object.Camera) {
+	if camera == nil || director.resize.policy != ResizeAspect {
+		return
+	}
+	if e := director.resize.event; e.FramebufferWidth > 0 && e.FramebufferHeight > 0 {
+		camera.SetAspect(core.Float(e.Aspect()))
+	}
+}
+
+// commitInput commits input events received since the last update, so
+// pressed and released edges are seen by the first fixed update of a frame
+// only, and kept for the next frame if no fixed update runs in this frame
+func commitInput() {
+	director.input.Update()
+	if director.gamepads != nil {
+		for _, e := range director.input.PollGamepads(director.gamepads) {
+			director.dispatcher.DispatchEvent(e)
+		}
+	}
+	updateActions()
+}
+
+// sceneCamera returns camera of the scene if it has one, or the director's
+// camera otherwise
+func sceneCamera(scene object.Scene) object.Camera {
+	if holder, ok := scene.(interface{ Camera() object.Camera }); ok {
+		if camera := holder.Camera(); camera != nil {
+			return camera
+		}
+	}
+	return director.camera
+}
+
+// RunScene exits all scenes and runs the scene
 func RunScene(scene object.Scene) {
+	RunSceneWithTransition(scene, nil)
+}
+
+// RunSceneWithTransition exits all scenes and runs the scene, transition
+// may be nil
+func RunSceneWithTransition(scene object.Scene, transition Transition) {
+	finishTransition()
+	var out object.Scene
 	if n := len(director.scenes); n > 0 {
-		director.scenes[n-1].OnExit()
-		director.scenes = director.scenes[:0]
+		out = director.scenes[n-1]
+		director.scenes[n-1] = nil
+		director.scenes = director.scenes[:n-1]
+		// covered scenes have been paused, they exit without transition
+		exitScenes(0)
 	}
-	PushScene(scene)
+	director.scenes = append(director.scenes, scene)
+	switchScene(out, scene, true, false, transition)
 }
 
-// PushScene push and runs the scene
-func PushScene(scene object.Scene) {
-	if n := len(director.scenes); n > 0 {
-		director.scenes[n-1].OnExit()
+// ReplaceScene replaces the running scene by the scene
+func ReplaceScene(scene object.Scene) {
+	ReplaceSceneWithTransition(scene, nil)
+}
+
+// ReplaceSceneWithTransition replaces the running scene by the scene,
+// transition may be nil
+func ReplaceSceneWithTransition(scene object.Scene, transition Transition) {
+	finishTransition()
+	var out = GetRunningScene()
+	if out == nil {
+		director.scenes = append(director.scenes, scene)
+	} else {
+		director.scenes[len(director.scenes)-1] = scene
 	}
+	switchScene(out, scene, true, false, transition)
+}
+
+// PushScene pauses the running scene and runs the scene
+func PushScene(scene object.Scene) {
+	PushSceneWithTransition(scene, nil)
+}
+
+// PushSceneWithTransition pauses the running scene and runs the scene,
+// transition may be nil
+func PushSceneWithTransition(scene object.Scene, transition Transition) {
+	finishTransition()
+	var out = GetRunningScene()
 	director.scenes = append(director.scenes, scene)
-	scene.OnEnter()
+	switchScene(out, scene, false, false, transition)
 }
 
-// PopScene pops current running scene and runs previous scene
+// PopScene exits the running scene and resumes the previous scene, the
+// argument is ignored, the running scene is always popped
 func PopScene(scene object.Scene) {
-	if n := len(director.scenes); n > 0 {
-		director.scenes[n-1].OnExit()
-		director.scenes = director.scenes[:n-1]
-		if n > 1 {
-			director.scenes[n-2].OnEnter()
+	PopSceneWithTransition(nil)
+}
+
+// PopSceneWithTransition exits the running scene and resumes the previous
+// scene, transition may be nil
+func PopSceneWithTransition(transition Transition) {
+	finishTransition()
+	var n = len(director.scenes)
+	if n == 0 {
+		return
+	}
+	var out = director.scenes[n-1]
+	director.scenes[n-1] = nil
+	director.scenes = director.scenes[:n-1]
+	switchScene(out, GetRunningScene(), true, true, transition)
+}
+
+// PopToRootScene exits all scenes except the root scene and resumes the
+// root scene
+func PopToRootScene() {
+	PopToRootSceneWithTransition(nil)
+}
+
+// PopToRootSceneWithTransition exits all scenes except the root scene and
+// resumes the root scene, transition may be nil
+func PopToRootSceneWithTransition(transition Transition) {
+	finishTransition()
+	var n = len(director.scenes)
+	if n <= 1 {
+		return
+	}
+	var out = director.scenes[n-1]
+	director.scenes[n-1] = nil
+	director.scenes = director.scenes[:n-1]
+	exitScenes(1)
+	switchScene(out, GetRunningScene(), true, true, transition)
+}
+
+// exitScenes exits and removes scenes from top to index
+func exitScenes(index int) {
+	for i := len(director.scenes) - 1; i >= index; i-- {
+		leaveScene(director.scenes[i], true)
+		director.scenes[i] = nil
+	}
+	if index < len(director.scenes) {
+		director.scenes = director.scenes[:index]
+	}
+}
+
+// switchScene switches from scene out to scene in, out exits if exit is
+// true or it's paused, in resumes if resume is true or it enters
+func switchScene(out, in object.Scene, exit, resume bool, transition Transition) {
+	if transition != nil && out != nil && in != nil && director.renderer != nil {
+		if err := startTransition(out, in, exit, transition); err != nil {
+			core.ReportError(fmt.Errorf("director: transition: %w", err))
+		} else {
+			enterScene(in, resume)
+			return
+		}
+	}
+	leaveScene(out, exit)
+	enterScene(in, resume)
+	if in != nil {
+		in.OnEnterTransitionDidFinish()
+	}
+}
+
+func enterScene(scene object.Scene, resume bool) {
+	if scene == nil {
+		return
+	}
+	core.LogScene.Debug(operator.If(resume, "resume scene", "enter scene"), "scene", fmt.Sprintf("%T", scene))
+	fitCamera(sceneCamera(scene))
+	if actions := SceneActions(scene); actions != nil {
+		ActivateActions(actions)
+	}
+	if resume {
+		scene.OnResume()
+	} else {
+		scene.OnEnter()
+	}
+}
+
+func leaveScene(scene object.Scene, exit bool) {
+	if scene == nil {
+		return
+	}
+	core.LogScene.Debug(operator.If(exit, "exit scene", "pause scene"), "scene", fmt.Sprintf("%T", scene))
+	if actions := SceneActions(scene); actions != nil {
+		DeactivateActions(actions)
+	}
+	if exit {
+		scene.OnExit()
+	} else {
+		scene.OnPause()
+	}
+}
+
+// Input returns polled input state, it's updated once per frame
+func Input() *input.State {
+	return &director.input
+}
+
+// SetGamepadSource replaces the source of gamepad states, e.g. by a fake
+// source to drive gamepads in tests, nil restores the source of window
+func SetGamepadSource(source input.GamepadSource) {
+	if source == nil && director.window != nil {
+		source = director.window.GamepadSource()
+	}
+	director.gamepads = source
+}
+
+// ActivateActions activates the action context, active contexts are
+// updated once per frame and their ActionEvents are dispatched by the
+// director's dispatcher
+func ActivateActions(actions *input.ActionContext) {
+	for _, ctx := range director.actions {
+		if ctx == actions {
+			return
+		}
+	}
+	director.actions = append(director.actions, actions)
+}
+
+// DeactivateActions deactivates the action context and resets its state
+func DeactivateActions(actions *input.ActionContext) {
+	for i, ctx := range director.actions {
+		if ctx == actions {
+			director.actions = append(director.actions[:i:i], director.actions[i+1:]...)
+			actions.Reset()
+			return
+		}
+	}
+}
+
+// ActiveActions returns active action contexts
+func ActiveActions() []*input.ActionContext {
+	return director.actions
+}
+
+// SetSceneActions sets the action context of scene, the director activates
+// it while the scene is running and deactivates it when the scene exits or
+// is paused. The association is kept until SetSceneActions(scene, nil)
+func SetSceneActions(scene object.Scene, actions *input.ActionContext) {
+	var old = director.sceneActions[scene]
+	if old == actions {
+		return
+	}
+	var running = scene == GetRunningScene()
+	if old != nil && running {
+		DeactivateActions(old)
+	}
+	if actions == nil {
+		delete(director.sceneActions, scene)
+		return
+	}
+	if director.sceneActions == nil {
+		director.sceneActions = make(map[object.Scene]*input.ActionContext)
+	}
+	director.sceneActions[scene] = actions
+	if running {
+		ActivateActions(actions)
+	}
+}
+
+// SceneActions returns the action context of scene set by SetSceneActions
+func SceneActions(scene object.Scene) *input.ActionContext {
+	return director.sceneActions[scene]
+}
+
+func updateActions() {
+	// listeners may activate or deactivate contexts
+	var actions = director.actions
+	for _, ctx := range actions {
+		for _, e := range ctx.Update(&director.input) {
+			director.dispatcher.DispatchEvent(e)
 		}
 	}
 }
@@ -0,0 +1,74 @@
+package director
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gopherd/three/input"
+	"github.com/gopherd/three/object"
+)
+
+// lifecycleScene records calls of lifecycle methods
+type lifecycleScene struct {
+	object.BasicScene
+	name  string
+	calls *[]string
+}
+
+func (scene *lifecycleScene) OnEnter()  { *scene.calls = append(*scene.calls, scene.name+" enter") }
+func (scene *lifecycleScene) OnExit()   { *scene.calls = append(*scene.calls, scene.name+" exit") }
+func (scene *lifecycleScene) OnPause()  { *scene.calls = append(*scene.calls, scene.name+" pause") }
+func (scene *lifecycleScene) OnResume() { *scene.calls = append(*scene.calls, scene.name+" resume") }
+
+func TestSceneStack(t *testing.T) {
+	var calls []string
+	var a = &lifecycleScene{name: "a", calls: &calls}
+	var b = &lifecycleScene{name: "b", calls: &calls}
+	var c = &lifecycleScene{name: "c", calls: &calls}
+	defer exitScenes(0)
+
+	RunScene(a)
+	PushScene(b)
+	PushSceneWithTransition(c, nil)
+	PopScene(nil)
+	if got := GetRunningScene(); got != b {
+		t.Fatalf("running scene is %v, want b", got)
+	}
+	PopToRootScene()
+	RunScene(c)
+	var want = []string{
+		"a enter",
+		"a pause", "b enter",
+		"b pause", "c enter",
+		"c exit", "b resume",
+		"b exit", "a resume",
+		"a exit", "c enter",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("got calls %q, want %q", calls, want)
+	}
+}
+
+func TestSceneActions(t *testing.T) {
+	var a, b = new(object.BasicScene), new(object.BasicScene)
+	var actionsA, actionsB = input.NewActionContext("a"), input.NewActionContext("b")
+	defer exitScenes(0)
+	defer SetSceneActions(a, nil)
+	defer SetSceneActions(b, nil)
+
+	SetSceneActions(a, actionsA)
+	RunScene(a)
+	PushScene(b)
+	SetSceneActions(b, actionsB)
+	if got := ActiveActions(); !reflect.DeepEqual(got, []*input.ActionContext{actionsB}) {
+		t.Fatalf("active actions of pushed scene = %v", got)
+	}
+	PopScene(nil)
+	if got := ActiveActions(); !reflect.DeepEqual(got, []*input.ActionContext{actionsA}) {
+		t.Fatalf("active actions of resumed scene = %v", got)
+	}
+	SetSceneActions(a, nil)
+	if got := ActiveActions(); len(got) != 0 {
+		t.Fatalf("active actions after unset = %v", got)
+	}
+}
@@ -0,0 +1,185 @@
+package director
+
+import (
+	"math"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/object"
+)
+
+// Rect is a rectangle in units of framebuffer size with the origin at the
+// top-left corner, e.g. Rect{X: 0.5, Width: 0.5, Height: 1} is the right
+// half of framebuffer
+type Rect struct {
+	X, Y, Width, Height float32
+}
+
+// FullRect covers the whole framebuffer
+var FullRect = Rect{Width: 1, Height: 1}
+
+// IsEmpty reports whether the rectangle has no area
+func (r Rect) IsEmpty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// pixels converts the rectangle to pixels in the framebuffer of size, the
+// returned y is from the bottom as OpenGL expects
+func (r Rect) pixels(width, height int32) (x, y, w, h int32) {
+	var round = func(v float32, size int32) int32 {
+		return int32(math.Round(float64(v * float32(size))))
+	}
+	x, w = round(r.X, width), round(r.X+r.Width, width)
+	var top, bottom = round(r.Y, height), round(r.Y+r.Height, height)
+	return x, height - bottom, w - x, bottom - top
+}
+
+// Pass describes a rendering of the running scene in a frame, passes are
+// executed in order, e.g. split-screen views render a pass per player and
+// picture-in-picture renders an overlay pass after the main pass
+type Pass struct {
+	// Camera renders the pass, it's the camera of scene or director if nil
+	Camera object.Camera
+	// Viewport is the area to render to, the whole framebuffer if empty
+	Viewport Rect
+	// Scissor restricts clearing and drawing, it's the viewport if empty
+	Scissor Rect
+	// KeepColor disables clearing color buffer with background of scene
+	KeepColor bool
+	// KeepDepth disables clearing depth and stencil buffers
+	KeepDepth bool
+	// Layers selects objects to render in addition to the layer mask of
+	// camera, all layers of the mask if zero
+	Layers object.Layers
+	// Tiled renders the viewport part of what camera sees over the whole
+	// framebuffer by Camera.SetViewOffset, e.g. to span a view across
+	// viewports. Otherwise aspect of camera is matched to the viewport if
+	// resize policy is ResizeAspect.
+	Tiled bool
+}
+
+// setupCamera adapts camera to the pass rendered to x, y, w, h in pixels of
+// framebuffer of size vw, vh, view offset of camera must be cleared after
+// use if the pass is tiled
+func (pass Pass) setupCamera(camera object.Camera, vw, vh, x, y, w, h int32) {
+	if pass.Tiled {
+		var top = vh - y - h
+		camera.SetViewOffset(core.Float(vw), core.Float(vh), core.Float(x), core.Float(top), core.Float(w), core.Float(h))
+	} else if director.resize.policy == ResizeAspect {
+		camera.SetAspect(core.Float(w) / core.Float(h))
+	}
+}
+
+// viewport returns viewport of the pass, the whole framebuffer if empty
+func (pass Pass) viewport() Rect {
+	if pass.Viewport.IsEmpty() {
+		return FullRect
+	}
+	return pass.Viewport
+}
+
+// contains reports whether the point in units of framebuffer size is in
+// the rectangle
+func (r Rect) contains(x, y float32) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// passAt returns the topmost pass drawn at the point in units of framebuffer
+// size, the default pass is returned if no passes set
+func passAt(x, y float32) (Pass, bool) {
+	if len(director.passes) == 0 {
+		return Pass{}, true
+	}
+	for i := len(director.passes) - 1; i >= 0; i-- {
+		var pass = director.passes[i]
+		if !pass.viewport().contains(x, y) {
+			continue
+		}
+		if !pass.Scissor.IsEmpty() && !pass.Scissor.contains(x, y) {
+			continue
+		}
+		return pass, true
+	}
+	return Pass{}, false
+}
+
+// SetPasses sets render passes executed per frame, the running scene is
+// rendered once by its camera to the whole framebuffer if no passes set.
+// Passes apply to the default framebuffer, scenes are rendered by their
+// cameras while transitioning.
+func SetPasses(passes ...Pass) {
+	director.passes = append([]Pass(nil), passes...)
+}
+
+// GetPasses returns render passes
+func GetPasses() []Pass {
+	return director.passes
+}
+
+// renderPasses renders the scene by passes and returns accumulated stats
+func renderPasses(scene object.Scene) object.RenderStats {
+	if len(director.passes) == 0 {
+		var camera = sceneCamera(scene)
+		if camera == nil {
+			return object.RenderStats{}
+		}
+		scene.Render(director.renderer, camera)
+		return scene.RenderStats()
+	}
+	var r = director.renderer
+	var vx, vy, vw, vh = r.GetViewport()
+	defer func() {
+		r.Scissor(0, 0, 0, 0)
+		r.Viewport(vx, vy, vw, vh)
+		// restores aspect of the scene camera for picking by pointer
+		fitCamera(sceneCamera(scene))
+	}()
+	var stats object.RenderStats
+	for _, pass := range director.passes {
+		var camera = pass.Camera
+		if camera == nil {
+			camera = sceneCamera(scene)
+		}
+		if camera == nil {
+			continue
+		}
+		var viewport = pass.viewport()
+		var x, y, w, h = viewport.pixels(vw, vh)
+		if w <= 0 || h <= 0 {
+			continue
+		}
+		r.Viewport(vx+x, vy+y, w, h)
+
+		var scissor = pass.Scissor
+		if scissor.IsEmpty() {
+			scissor = viewport
+		}
+		if scissor == FullRect {
+			r.Scissor(0, 0, 0, 0)
+		} else {
+			var x, y, w, h = scissor.pixels(vw, vh)
+			r.Scissor(vx+x, vy+y, w, h)
+		}
+
+		pass.setupCamera(camera, vw, vh, x, y, w, h)
+		scene.RenderPass(r, object.RenderPass{
+			Camera:    camera,
+			KeepColor: pass.KeepColor,
+			KeepDepth: pass.KeepDepth,
+			Layers:    pass.Layers,
+		})
+		if pass.Tiled {
+			camera.ClearViewOffset()
+		}
+		stats = addRenderStats(stats, scene.RenderStats())
+	}
+	return stats
+}
+
+func addRenderStats(a, b object.RenderStats) object.RenderStats {
+	a.Culled += b.Culled
+	a.Drawn += b.Drawn
+	a.Cull += b.Cull
+	a.Sort += b.Sort
+	a.Submit += b.Submit
+	return a
+}
@@ -0,0 +1,35 @@
+package director
+
+import "testing"
+
+func TestPassAt(t *testing.T) {
+	var left = Pass{Viewport: Rect{Width: 0.5, Height: 1}}
+	var right = Pass{Viewport: Rect{X: 0.5, Width: 0.5, Height: 1}, KeepDepth: true}
+	var overlay = Pass{Viewport: Rect{X: 0.75, Y: 0, Width: 0.25, Height: 0.25}, KeepColor: true}
+	var scissored = Pass{Scissor: Rect{Width: 0.1, Height: 0.1}, KeepColor: true, KeepDepth: true}
+	for _, tt := range []struct {
+		name   string
+		passes []Pass
+		x, y   float32
+		want   Pass
+		wantOk bool
+	}{
+		{name: "no passes", x: 0.9, y: 0.9, wantOk: true},
+		{name: "left", passes: []Pass{left, right}, x: 0.2, y: 0.5, want: left, wantOk: true},
+		{name: "right", passes: []Pass{left, right}, x: 0.5, y: 0.5, want: right, wantOk: true},
+		{name: "overlay on top", passes: []Pass{left, right, overlay}, x: 0.8, y: 0.1, want: overlay, wantOk: true},
+		{name: "under overlay", passes: []Pass{left, right, overlay}, x: 0.8, y: 0.5, want: right, wantOk: true},
+		{name: "outside scissor", passes: []Pass{scissored}, x: 0.5, y: 0.5},
+		{name: "inside scissor", passes: []Pass{scissored}, x: 0.05, y: 0.05, want: scissored, wantOk: true},
+		{name: "uncovered", passes: []Pass{left}, x: 0.7, y: 0.5},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			SetPasses(tt.passes...)
+			defer SetPasses()
+			got, ok := passAt(tt.x, tt.y)
+			if ok != tt.wantOk || got != tt.want {
+				t.Fatalf("got %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package director
+
+import (
+	"github.com/gopherd/doge/operator"
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/driver/window"
+	"github.com/gopherd/three/input"
+	"github.com/gopherd/three/object"
+)
+
+var pointer struct {
+	x, y    float64
+	mods    input.ModifierKey
+	hovered object.Object
+	pressed [input.MouseButtonLast + 1]object.Object
+	capture object.Object
+	locked  bool
+}
+
+// SetPointerCapture captures the pointer to the object, pointer events are
+// targeted at the object regardless of objects under the pointer until
+// ReleasePointerCapture called or the object removed from the running scene
+func SetPointerCapture(target object.Object) {
+	pointer.capture = target
+}
+
+// ReleasePointerCapture releases the pointer captured by SetPointerCapture
+func ReleasePointerCapture() {
+	pointer.capture = nil
+}
+
+// PointerCapture returns the object which captures the pointer
+func PointerCapture() object.Object {
+	releaseDetached(GetRunningScene())
+	return pointer.capture
+}
+
+// releaseDetached drops hovered, pressed and capturing objects which have
+// been removed from the scene, or whose ancestors have been removed
+func releaseDetached(scene object.Scene) {
+	if pointer.hovered != nil && !inScene(scene, pointer.hovered) {
+		pointer.hovered = nil
+	}
+	for i, pressed := range pointer.pressed {
+		if pressed != nil && !inScene(scene, pressed) {
+			pointer.pressed[i] = nil
+		}
+	}
+	if pointer.capture != nil && !inScene(scene, pointer.capture) {
+		pointer.capture = nil
+	}
+}
+
+// inScene reports whether the object is in the tree of scene
+func inScene(scene object.Scene, obj object.Object) bool {
+	if scene == nil {
+		return false
+	}
+	for obj.Parent() != nil {
+		obj = obj.Parent()
+	}
+	return scene.GetChildByUUID(obj.UUID()) == obj
+}
+
+// SetPointerLock hides the cursor and locks it to the window, e.g. for
+// first-person cameras, mouse movement is reported by MouseMoveEvent deltas
+func SetPointerLock(locked bool) {
+	pointer.locked = locked
+	if director.window != nil {
+		director.window.SetCursorMode(operator.If(locked, window.CursorLocked, window.CursorNormal))
+	}
+}
+
+// IsPointerLocked reports whether the pointer is locked
+func IsPointerLocked() bool {
+	return pointer.locked
+}
+
+// Pick returns the nearest intersection of visible objects in the running
+// scene under the point in screen coordinates. The ray is cast by camera of
+// the topmost pass under the point through its viewport, objects out of
+// layers of the pass are ignored.
+func Pick(x, y float64) (object.Intersection, bool) {
+	var scene = GetRunningScene()
+	var size = director.resize.event
+	if scene == nil || size.Width <= 0 || size.Height <= 0 {
+		return object.Intersection{}, false
+	}
+	var u, v = float32(x / float64(size.Width)), float32(y / float64(size.Height))
+	pass, ok := passAt(u, v)
+	if !ok {
+		return object.Intersection{}, false
+	}
+	var camera = pass.Camera
+	if camera == nil {
+		camera = sceneCamera(scene)
+	}
+	if camera == nil {
+		return object.Intersection{}, false
+	}
+	var viewport = pass.viewport()
+	if len(director.passes) > 0 {
+		var vw, vh = int32(size.FramebufferWidth), int32(size.FramebufferHeight)
+		var px, py, pw, ph = viewport.pixels(vw, vh)
+		if pw <= 0 || ph <= 0 {
+			return object.Intersection{}, false
+		}
+		// cameras are set up as rendering the pass and restored after
+		// picking like renderPasses does
+		pass.setupCamera(camera, vw, vh, px, py, pw, ph)
+		defer func() {
+			if pass.Tiled {
+				camera.ClearViewOffset()
+			}
+			fitCamera(sceneCamera(scene))
+		}()
+	}
+	var ndcX = core.Float(2*(u-viewport.X)/viewport.Width - 1)
+	var ndcY = core.Float(1 - 2*(v-viewport.Y)/viewport.Height)
+	var layers = camera.LayerMask() & operator.Or(pass.Layers, object.AllLayers)
+	var intersections = object.RaycastLayers(scene, object.RayFromCamera(camera, ndcX, ndcY), layers)
+	if len(intersections) == 0 {
+		return object.Intersection{}, false
+	}
+	return intersections[0], true
+}
+
+// pointerTarget returns the object targeted by pointer events
+func pointerTarget() (object.Object, object.Intersection) {
+	hit, ok := Pick(pointer.x, pointer.y)
+	if pointer.capture != nil {
+		if !ok || hit.Object != pointer.capture {
+			hit = object.Intersection{Object: pointer.capture}
+		}
+		return pointer.capture, hit
+	}
+	if !ok {
+		return nil, hit
+	}
+	return hit.Object, hit
+}
+
+func newPointerEvent(target object.Object, hit object.Intersection, button input.MouseButton) object.PointerEvent {
+	return object.PointerEvent{
+		Target:        target,
+		CurrentTarget: target,
+		Button:        button,
+		Mods:          pointer.mods,
+		X:             pointer.x,
+		Y:             pointer.y,
+		Hit:           hit,
+	}
+}
+
+// handlePointer translates input events to pointer events of objects
+func handlePointer(e event.Event) {
+	var scene = GetRunningScene()
+	releaseDetached(scene)
+	switch e := e.(type) {
+	case input.MouseMoveEvent:
+		pointer.x, pointer.y = e.X, e.Y
+		var target, hit = pointerTarget()
+		hover(target, hit)
+		if target != nil {
+			object.DispatchPointerEvent(scene, &object.PointerMoveEvent{
+				PointerEvent: newPointerEvent(target, hit, 0),
+			})
+		}
+	case input.MouseButtonEvent:
+		if e.Button < 0 || e.Button > input.MouseButtonLast {
+			return
+		}
+		pointer.x, pointer.y, pointer.mods = e.X, e.Y, e.Mods
+		var target, hit = pointerTarget()
+		if e.Action == input.Press {
+			pointer.pressed[e.Button] = target
+			if target != nil {
+				object.DispatchPointerEvent(scene, &object.PointerDownEvent{
+					PointerEvent: newPointerEvent(target, hit, e.Button),
+				})
+			}
+		} else if e.Action == input.Release {
+			var pressed = pointer.pressed[e.Button]
+			pointer.pressed[e.Button] = nil
+			if target != nil {
+				object.DispatchPointerEvent(scene, &object.PointerUpEvent{
+					PointerEvent: newPointerEvent(target, hit, e.Button),
+				})
+				if pressed == target {
+					object.DispatchPointerEvent(scene, &object.ClickEvent{
+						PointerEvent: newPointerEvent(target, hit, e.Button),
+					})
+				}
+			}
+		}
+	case input.CursorEnterEvent:
+		if !e.Entered {
+			hover(nil, object.Intersection{})
+		}
+	}
+}
+
+// hover fires leave and enter events if the hovered object changed
+func hover(target object.Object, hit object.Intersection) {
+	if pointer.hovered == target {
+		return
+	}
+	if old := pointer.hovered; old != nil {
+		old.DispatchEvent(&object.PointerLeaveEvent{
+			PointerEvent: newPointerEvent(old, object.Intersection{}, 0),
+		})
+	}
+	pointer.hovered = target
+	if target != nil {
+		target.DispatchEvent(&object.PointerEnterEvent{
+			PointerEvent: newPointerEvent(target, hit, 0),
+		})
+	}
+}
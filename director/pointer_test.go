@@ -0,0 +1,41 @@
+package director
+
+import (
+	"testing"
+
+	"github.com/gopherd/three/input"
+	"github.com/gopherd/three/object"
+)
+
+func TestPointerReleasesRemovedObjects(t *testing.T) {
+	var node = func() object.Object { return object.NewMesh(nil, nil) }
+	var scene = new(object.BasicScene)
+	var parent, child, moved = node(), node(), node()
+	var from, to = object.NewGroup(), object.NewGroup()
+	object.Attatch(parent, child)
+	from.Add(moved)
+	scene.Add(parent)
+	scene.Add(from)
+	scene.Add(to)
+	RunScene(scene)
+	defer exitScenes(0)
+
+	pointer.hovered = child
+	pointer.pressed[input.MouseButtonLeft] = child
+	pointer.pressed[input.MouseButtonRight] = moved
+	SetPointerCapture(child)
+	defer ReleasePointerCapture()
+
+	scene.RemoveChild(parent)
+	to.Add(moved)
+	if got := PointerCapture(); got != nil {
+		t.Fatalf("capture is %v after removed", got)
+	}
+	if pointer.hovered != nil || pointer.pressed[input.MouseButtonLeft] != nil {
+		t.Fatal("removed object is still hovered or pressed")
+	}
+	if pointer.pressed[input.MouseButtonRight] != moved {
+		t.Fatal("moved object is released")
+	}
+	pointer.pressed[input.MouseButtonRight] = nil
+}
@@ -0,0 +1,292 @@
+package director
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/object"
+)
+
+// TimerID identifies a scheduled callback
+type TimerID int64
+
+type timer struct {
+	id       TimerID
+	target   object.Object
+	priority int
+	interval time.Duration
+	elapsed  time.Duration
+	repeat   int // repeat is remaining times of firing, negative if forever
+	fire     func()
+	update   func(dt time.Duration)
+	canceled bool
+}
+
+type targetState struct {
+	listener int // id of DetachedEvent listener
+	timers   int
+	paused   bool
+}
+
+// eventListener is implemented by objects which accept event listeners
+type eventListener interface {
+	AddEventListener(event.Listener) int
+	RemoveEventListener(id int) bool
+}
+
+// Scheduler schedules timers and per-frame update callbacks driven by game
+// clock. Callbacks owned by an object are canceled automatically when the
+// object or any of its ancestors is removed from the tree, but not when it's
+// moved to another parent.
+type Scheduler struct {
+	nextID    TimerID
+	timeScale float64
+	updating  bool
+	updates   []*timer // updates sorted by priority
+	timers    []*timer
+	pending   []*timer // pending callbacks added while updating
+	byID      map[TimerID]*timer
+	targets   map[object.Object]*targetState
+}
+
+// NewScheduler creates a Scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		timeScale: 1,
+		byID:      make(map[TimerID]*timer),
+		targets:   make(map[object.Object]*targetState),
+	}
+}
+
+// TimeScale returns the scale of delta time passed to callbacks
+func (s *Scheduler) TimeScale() float64 {
+	return s.timeScale
+}
+
+// SetTimeScale sets the scale of delta time passed to callbacks, e.g. 0.5
+// for slow motion, 0 freezes all callbacks
+func (s *Scheduler) SetTimeScale(timeScale float64) {
+	s.timeScale = timeScale
+}
+
+// Schedule calls fn once after delay, target may be nil
+func (s *Scheduler) Schedule(target object.Object, delay time.Duration, fn func()) TimerID {
+	return s.add(&timer{
+		target:   target,
+		interval: delay,
+		repeat:   1,
+		fire:     fn,
+	})
+}
+
+// ScheduleRepeat calls fn every interval, repeat times or forever if
+// repeat <= 0, target may be nil
+func (s *Scheduler) ScheduleRepeat(target object.Object, interval time.Duration, repeat int, fn func()) TimerID {
+	if repeat <= 0 {
+		repeat = -1
+	}
+	return s.add(&timer{
+		target:   target,
+		interval: interval,
+		repeat:   repeat,
+		fire:     fn,
+	})
+}
+
+// NextFrame calls fn once on next update, target may be nil
+func (s *Scheduler) NextFrame(target object.Object, fn func()) TimerID {
+	return s.Schedule(target, 0, fn)
+}
+
+// ScheduleUpdate calls fn with scaled delta time every update, callbacks
+// with lower priority are called first, target may be nil
+func (s *Scheduler) ScheduleUpdate(target object.Object, priority int, fn func(dt time.Duration)) TimerID {
+	return s.add(&timer{
+		target:   target,
+		priority: priority,
+		repeat:   -1,
+		update:   fn,
+	})
+}
+
+// Unschedule cancels the scheduled callback
+func (s *Scheduler) Unschedule(id TimerID) bool {
+	t, ok := s.byID[id]
+	if !ok {
+		return false
+	}
+	s.cancel(t)
+	return true
+}
+
+// UnscheduleAll cancels all callbacks of target
+func (s *Scheduler) UnscheduleAll(target object.Object) {
+	for _, t := range s.byID {
+		if t.target == target {
+			s.cancel(t)
+		}
+	}
+}
+
+// Pause pauses callbacks of target, elapsed time of paused timers is frozen
+func (s *Scheduler) Pause(target object.Object) {
+	if state, ok := s.targets[target]; ok {
+		state.paused = true
+	}
+}
+
+// Resume resumes paused callbacks of target
+func (s *Scheduler) Resume(target object.Object) {
+	if state, ok := s.targets[target]; ok {
+		state.paused = false
+	}
+}
+
+// IsPaused reports whether callbacks of target are paused
+func (s *Scheduler) IsPaused(target object.Object) bool {
+	state, ok := s.targets[target]
+	return ok && state.paused
+}
+
+func (s *Scheduler) add(t *timer) TimerID {
+	s.nextID++
+	t.id = s.nextID
+	s.byID[t.id] = t
+	if t.target != nil {
+		s.attach(t.target)
+	}
+	if s.updating {
+		s.pending = append(s.pending, t)
+	} else {
+		s.insert(t)
+	}
+	return t.id
+}
+
+func (s *Scheduler) insert(t *timer) {
+	if t.update == nil {
+		s.timers = append(s.timers, t)
+		return
+	}
+	var i = sort.Search(len(s.updates), func(i int) bool {
+		return s.updates[i].priority > t.priority
+	})
+	s.updates = append(s.updates, nil)
+	copy(s.updates[i+1:], s.updates[i:])
+	s.updates[i] = t
+}
+
+// attach listens DetachedEvent of target once to cancel its callbacks
+func (s *Scheduler) attach(target object.Object) {
+	state, ok := s.targets[target]
+	if !ok {
+		state = &targetState{}
+		if listener, ok := target.(eventListener); ok {
+			state.listener = listener.AddEventListener(event.Listen(object.DetachedEventType, func(object.DetachedEvent) {
+				s.UnscheduleAll(target)
+				state.paused = false
+			}))
+		}
+		s.targets[target] = state
+	}
+	state.timers++
+}
+
+// detach removes listener of target if it has no callbacks, it's never
+// called by the DetachedEvent listener itself
+func (s *Scheduler) detach(target object.Object) {
+	state, ok := s.targets[target]
+	if !ok {
+		return
+	}
+	state.timers--
+	if state.timers > 0 {
+		return
+	}
+	if listener, ok := target.(eventListener); ok && state.listener != 0 {
+		listener.RemoveEventListener(state.listener)
+	}
+	delete(s.targets, target)
+}
+
+func (s *Scheduler) cancel(t *timer) {
+	t.canceled = true
+	delete(s.byID, t.id)
+}
+
+func (s *Scheduler) paused(t *timer) bool {
+	if t.target == nil {
+		return false
+	}
+	state, ok := s.targets[t.target]
+	return ok && state.paused
+}
+
+// update calls callbacks by delta time dt, callbacks added while updating
+// are called from next update
+func (s *Scheduler) update(dt time.Duration) {
+	dt = time.Duration(float64(dt) * s.timeScale)
+	s.updating = true
+	for _, t := range s.updates {
+		if !t.canceled && !s.paused(t) {
+			t.update(dt)
+		}
+	}
+	for _, t := range s.timers {
+		if t.canceled || s.paused(t) {
+			continue
+		}
+		t.elapsed += dt
+		for !t.canceled && t.elapsed >= t.interval {
+			if t.interval > 0 {
+				t.elapsed -= t.interval
+			} else {
+				// fires at most once per update
+				t.elapsed = 0
+			}
+			if t.repeat > 0 {
+				t.repeat--
+				if t.repeat == 0 {
+					s.cancel(t)
+				}
+			}
+			t.fire()
+			if t.interval <= 0 {
+				break
+			}
+		}
+	}
+	s.updating = false
+
+	s.updates = s.compact(s.updates)
+	s.timers = s.compact(s.timers)
+	for i, t := range s.pending {
+		if !t.canceled {
+			s.insert(t)
+		} else if t.target != nil {
+			s.detach(t.target)
+		}
+		s.pending[i] = nil
+	}
+	s.pending = s.pending[:0]
+}
+
+// compact removes canceled callbacks and keeps the order
+func (s *Scheduler) compact(timers []*timer) []*timer {
+	var n int
+	for _, t := range timers {
+		if t.canceled {
+			if t.target != nil {
+				s.detach(t.target)
+			}
+			continue
+		}
+		timers[n] = t
+		n++
+	}
+	for i := n; i < len(timers); i++ {
+		timers[i] = nil
+	}
+	return timers[:n]
+}
@@ -0,0 +1,136 @@
+package director
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gopherd/three/object"
+)
+
+func TestSchedulerTimers(t *testing.T) {
+	const ms = time.Millisecond
+	for _, tt := range []struct {
+		name     string
+		schedule func(s *Scheduler, fire func())
+		steps    []time.Duration // delta time of updates
+		want     []int           // want fired times after each update
+	}{
+		{
+			name:     "once",
+			schedule: func(s *Scheduler, fire func()) { s.Schedule(nil, 10*ms, fire) },
+			steps:    []time.Duration{5 * ms, 5 * ms, 20 * ms},
+			want:     []int{0, 1, 1},
+		},
+		{
+			name:     "next frame",
+			schedule: func(s *Scheduler, fire func()) { s.NextFrame(nil, fire) },
+			steps:    []time.Duration{0, 0},
+			want:     []int{1, 1},
+		},
+		{
+			name:     "repeat catches up",
+			schedule: func(s *Scheduler, fire func()) { s.ScheduleRepeat(nil, 10*ms, 3, fire) },
+			steps:    []time.Duration{25 * ms, 10 * ms, 10 * ms},
+			want:     []int{2, 3, 3},
+		},
+		{
+			name:     "zero interval fires once per update",
+			schedule: func(s *Scheduler, fire func()) { s.ScheduleRepeat(nil, 0, 0, fire) },
+			steps:    []time.Duration{10 * ms, 10 * ms, 10 * ms},
+			want:     []int{1, 2, 3},
+		},
+		{
+			name: "unscheduled",
+			schedule: func(s *Scheduler, fire func()) {
+				var id = s.Schedule(nil, 10*ms, fire)
+				s.Unschedule(id)
+			},
+			steps: []time.Duration{20 * ms},
+			want:  []int{0},
+		},
+		{
+			name: "time scale",
+			schedule: func(s *Scheduler, fire func()) {
+				s.SetTimeScale(0.5)
+				s.ScheduleRepeat(nil, 10*ms, 0, fire)
+			},
+			steps: []time.Duration{10 * ms, 10 * ms, 20 * ms},
+			want:  []int{0, 1, 2},
+		},
+		{
+			name: "added while updating",
+			schedule: func(s *Scheduler, fire func()) {
+				s.NextFrame(nil, func() { s.NextFrame(nil, fire) })
+			},
+			steps: []time.Duration{0, 0, 0},
+			want:  []int{0, 1, 1},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var s = NewScheduler()
+			var fired int
+			tt.schedule(s, func() { fired++ })
+			var got []int
+			for _, dt := range tt.steps {
+				s.update(dt)
+				got = append(got, fired)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerUpdatePriority(t *testing.T) {
+	var s = NewScheduler()
+	var order []int
+	for _, priority := range []int{2, -1, 1, 0} {
+		var priority = priority
+		s.ScheduleUpdate(nil, priority, func(time.Duration) {
+			order = append(order, priority)
+		})
+	}
+	s.update(time.Millisecond)
+	if want := []int{-1, 0, 1, 2}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("got order %v, want %v", order, want)
+	}
+}
+
+func TestSchedulerTargets(t *testing.T) {
+	var s = NewScheduler()
+	// meshes without geometry serve as plain nodes
+	var node = func() object.Object { return object.NewMesh(nil, nil) }
+	var root, other, parent, child = node(), node(), node(), node()
+	object.Attatch(root, parent)
+	object.Attatch(parent, child)
+
+	var fired int
+	s.ScheduleRepeat(child, 0, 0, func() { fired++ })
+	var step = func(name string, want int) {
+		t.Helper()
+		s.update(time.Millisecond)
+		if fired != want {
+			t.Fatalf("%s: fired %d times, want %d", name, fired, want)
+		}
+	}
+	step("attached", 1)
+
+	s.Pause(child)
+	if !s.IsPaused(child) {
+		t.Fatal("child is not paused")
+	}
+	step("paused", 1)
+	s.Resume(child)
+	step("resumed", 2)
+
+	object.Attatch(other, parent)
+	step("reparented", 3)
+
+	other.RemoveChild(parent)
+	step("ancestor removed", 3)
+	if len(s.targets) != 0 || len(s.byID) != 0 {
+		t.Fatalf("%d targets and %d timers remain", len(s.targets), len(s.byID))
+	}
+}
@@ -0,0 +1,197 @@
+package director
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/object"
+)
+
+// Transition animates switching from the outgoing scene to the incoming
+// scene, both scenes are rendered into offscreen targets while transiting
+type Transition interface {
+	// Duration returns duration of the transition
+	Duration() time.Duration
+	// Render composes textures of outgoing and incoming scenes to the
+	// default framebuffer by progress in [0, 1]
+	Render(renderer renderer.Renderer, out, in renderer.RenderTarget, progress float64) error
+}
+
+type transitionState struct {
+	Transition
+	out       object.Scene
+	in        object.Scene
+	exit      bool // exit reports whether out exits, otherwise it's paused
+	outTarget renderer.RenderTarget
+	inTarget  renderer.RenderTarget
+	elapsed   time.Duration
+}
+
+func (state *transitionState) active() bool {
+	return state.Transition != nil
+}
+
+func (state *transitionState) progress() float64 {
+	var duration = state.Duration()
+	if duration <= 0 || state.elapsed >= duration {
+		return 1
+	}
+	return float64(state.elapsed) / float64(duration)
+}
+
+func startTransition(out, in object.Scene, exit bool, transition Transition) error {
+	var _, _, width, height = director.renderer.GetViewport()
+	outTarget, err := director.renderer.CreateRenderTarget(int(width), int(height))
+	if err != nil {
+		return err
+	}
+	inTarget, err := director.renderer.CreateRenderTarget(int(width), int(height))
+	if err != nil {
+		director.renderer.DeleteRenderTarget(outTarget)
+		return err
+	}
+	director.transition = transitionState{
+		Transition: transition,
+		out:        out,
+		in:         in,
+		exit:       exit,
+		outTarget:  outTarget,
+		inTarget:   inTarget,
+	}
+	return nil
+}
+
+func updateTransition(dt time.Duration) {
+	if !director.transition.active() {
+		return
+	}
+	director.transition.elapsed += dt
+	if director.transition.elapsed >= director.transition.Duration() {
+		finishTransition()
+	}
+}
+
+// finishTransition finishes the running transition immediately
+func finishTransition() {
+	var state = director.transition
+	if !state.active() {
+		return
+	}
+	director.transition = transitionState{}
+	director.renderer.DeleteRenderTarget(state.outTarget)
+	director.renderer.DeleteRenderTarget(state.inTarget)
+	leaveScene(state.out, state.exit)
+	state.in.OnEnterTransitionDidFinish()
+}
+
+func renderTransition() {
+	var state = &director.transition
+	renderSceneTo(state.out, state.outTarget)
+	renderSceneTo(state.in, state.inTarget)
+	director.renderer.BindRenderTarget(renderer.RenderTarget{})
+	if err := state.Render(director.renderer, state.outTarget, state.inTarget, state.progress()); err != nil {
+		core.ReportError(fmt.Errorf("director: transition: %w", err))
+		finishTransition()
+	}
+}
+
+func renderSceneTo(scene object.Scene, target renderer.RenderTarget) {
+	director.renderer.BindRenderTarget(target)
+	if camera := sceneCamera(scene); camera != nil {
+		scene.Render(director.renderer, camera)
+	} else {
+		director.renderer.ClearColor(0, 0, 0, 1)
+	}
+}
+
+type fadeTransition struct {
+	duration time.Duration
+	color    core.Vector4
+}
+
+// NewFadeTransition creates a Transition which fades out the outgoing scene
+// to color and then fades in the incoming scene
+func NewFadeTransition(duration time.Duration, color core.Vector4) Transition {
+	return &fadeTransition{duration: duration, color: color}
+}
+
+// Duration implements Transition Duration method
+func (t *fadeTransition) Duration() time.Duration { return t.duration }
+
+// Render implements Transition Render method
+func (t *fadeTransition) Render(renderer renderer.Renderer, out, in renderer.RenderTarget, progress float64) error {
+	renderer.ClearColor(t.color.X(), t.color.Y(), t.color.Z(), t.color.W())
+	if progress < 0.5 {
+		return renderer.DrawTexture(out.Texture, float32(1-progress*2), 0, 0)
+	}
+	return renderer.DrawTexture(in.Texture, float32(progress*2-1), 0, 0)
+}
+
+type crossFadeTransition struct {
+	duration time.Duration
+}
+
+// NewCrossFadeTransition creates a Transition which blends the incoming
+// scene over the outgoing scene
+func NewCrossFadeTransition(duration time.Duration) Transition {
+	return &crossFadeTransition{duration: duration}
+}
+
+// Duration implements Transition Duration method
+func (t *crossFadeTransition) Duration() time.Duration { return t.duration }
+
+// Render implements Transition Render method
+func (t *crossFadeTransition) Render(renderer renderer.Renderer, out, in renderer.RenderTarget, progress float64) error {
+	renderer.ClearColor(0, 0, 0, 1)
+	if err := renderer.DrawTexture(out.Texture, 1, 0, 0); err != nil {
+		return err
+	}
+	return renderer.DrawTexture(in.Texture, float32(progress), 0, 0)
+}
+
+// SlideDirection represents direction of scenes moving in slide transition
+type SlideDirection int
+
+const (
+	SlideLeft SlideDirection = iota
+	SlideRight
+	SlideUp
+	SlideDown
+)
+
+type slideTransition struct {
+	duration  time.Duration
+	direction SlideDirection
+}
+
+// NewSlideTransition creates a Transition which pushes the outgoing scene
+// out by the incoming scene in direction
+func NewSlideTransition(duration time.Duration, direction SlideDirection) Transition {
+	return &slideTransition{duration: duration, direction: direction}
+}
+
+// Duration implements Transition Duration method
+func (t *slideTransition) Duration() time.Duration { return t.duration }
+
+// Render implements Transition Render method
+func (t *slideTransition) Render(renderer renderer.Renderer, out, in renderer.RenderTarget, progress float64) error {
+	var dx, dy float32
+	switch t.direction {
+	case SlideLeft:
+		dx = -1
+	case SlideRight:
+		dx = 1
+	case SlideUp:
+		dy = 1
+	case SlideDown:
+		dy = -1
+	}
+	var p = float32(progress)
+	renderer.ClearColor(0, 0, 0, 1)
+	if err := renderer.DrawTexture(out.Texture, 1, dx*p, dy*p); err != nil {
+		return err
+	}
+	return renderer.DrawTexture(in.Texture, 1, dx*(p-1), dy*(p-1))
+}
@@ -0,0 +1,295 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"reflect"
+
+	"github.com/gopherd/three/driver/renderer/shader"
+)
+
+// nullRenderer implements Renderer without GPU, it validates and counts
+// calls like a real renderer so it can drive the director headlessly, e.g.
+// in integration tests on machines without display
+type nullRenderer struct {
+	nextId         uint32
+	state          State
+	stateValid     bool
+	programs       programCache
+	programIds     map[uint32]bool
+	vertexArrays   map[uint32]*nullVertexArray
+	renderTargets  map[uint32]RenderTarget
+	renderTarget   RenderTarget
+	viewport       [4]int32
+	currentProgram uint32
+	uniformBlocks  map[string]int // uniformBlocks holds sizes of uniform blocks
+	stats          Stats
+}
+
+type nullVertexArray struct {
+	buffers map[uint32]int // buffers holds bytes of buffers by location
+	indices int            // indices is bytes of indices
+}
+
+// NullRenderer creates a Renderer which draws nothing, it pairs with the
+// headless window to run applications without display
+func NullRenderer() Renderer {
+	return &nullRenderer{
+		programIds:    make(map[uint32]bool),
+		vertexArrays:  make(map[uint32]*nullVertexArray),
+		renderTargets: make(map[uint32]RenderTarget),
+		uniformBlocks: make(map[string]int),
+	}
+}
+
+func (renderer *nullRenderer) newId() uint32 {
+	renderer.nextId++
+	return renderer.nextId
+}
+
+// Init implements Renderer Init method
+func (renderer *nullRenderer) Init(width, height int) error {
+	renderer.Viewport(0, 0, int32(width), int32(height))
+	renderer.stateValid = false
+	renderer.SetState(DefaultState())
+	return nil
+}
+
+// Viewport implements Renderer Viewport method
+func (renderer *nullRenderer) Viewport(x, y, w, h int32) {
+	renderer.viewport = [4]int32{x, y, w, h}
+}
+
+// GetViewport implements Renderer GetViewport method
+func (renderer *nullRenderer) GetViewport() (x, y, w, h int32) {
+	var v = renderer.viewport
+	return v[0], v[1], v[2], v[3]
+}
+
+// ClearColor implements Renderer ClearColor method
+func (renderer *nullRenderer) ClearColor(r, g, b, a float32) {}
+
+// Clear implements Renderer Clear method
+func (renderer *nullRenderer) Clear(mask ClearMask, r, g, b, a float32) {}
+
+// Scissor implements Renderer Scissor method
+func (renderer *nullRenderer) Scissor(x, y, w, h int32) {}
+
+// SetState implements Renderer SetState method
+func (renderer *nullRenderer) SetState(state State) {
+	if renderer.stateValid && renderer.state == state {
+		return
+	}
+	renderer.state = state
+	renderer.stateValid = true
+	renderer.stats.StateChanges++
+}
+
+// CreateProgram implements Renderer CreateProgram method
+func (renderer *nullRenderer) CreateProgram(vshader, fshader string) (Program, error) {
+	var program = Program{
+		Id:               renderer.newId(),
+		VertextShaderId:  renderer.newId(),
+		FragmentShaderId: renderer.newId(),
+	}
+	renderer.programIds[program.Id] = true
+	return program, nil
+}
+
+// ClearProgram implements Renderer ClearProgram method
+func (renderer *nullRenderer) ClearProgram(program Program) {
+	if renderer.currentProgram == program.Id {
+		renderer.currentProgram = 0
+	}
+	delete(renderer.programIds, program.Id)
+}
+
+// AcquireProgram implements Renderer AcquireProgram method, sources are
+// preprocessed as the OpenGL renderer does
+func (renderer *nullRenderer) AcquireProgram(source shader.Shader) (Program, error) {
+	return renderer.programs.acquire(renderer, shader.GLSL330, source)
+}
+
+// ReleaseProgram implements Renderer ReleaseProgram method
+func (renderer *nullRenderer) ReleaseProgram(program Program) {
+	renderer.programs.release(renderer, program)
+}
+
+// LinkProgram implements Renderer LinkProgram method
+func (renderer *nullRenderer) LinkProgram(program uint32) error {
+	return nil
+}
+
+// UseProgram implements Renderer UseProgram method
+func (renderer *nullRenderer) UseProgram(program uint32) {
+	if renderer.currentProgram == program {
+		return
+	}
+	renderer.currentProgram = program
+	renderer.stats.ProgramBinds++
+}
+
+// Uniforms implements Renderer Uniforms method, programs have no active
+// uniforms
+func (renderer *nullRenderer) Uniforms(program uint32) []UniformInfo {
+	return nil
+}
+
+// Attributes implements Renderer Attributes method, programs have no
+// active attributes
+func (renderer *nullRenderer) Attributes(program uint32) []AttributeInfo {
+	return nil
+}
+
+// SetUniform implements Renderer SetUniform method
+func (renderer *nullRenderer) SetUniform(program uint32, name string, uniform shader.Uniform) error {
+	return nil
+}
+
+// SetUniformBlock implements Renderer SetUniformBlock method, the value is
+// encoded to report layout errors
+func (renderer *nullRenderer) SetUniformBlock(name string, value any) error {
+	data, err := EncodeStd140(value)
+	if err != nil {
+		return err
+	}
+	renderer.uniformBlocks[name] = len(data)
+	return nil
+}
+
+// CreateVertexArray implements Renderer CreateVertexArray method
+func (renderer *nullRenderer) CreateVertexArray() uint32 {
+	var id = renderer.newId()
+	renderer.vertexArrays[id] = &nullVertexArray{buffers: make(map[uint32]int)}
+	return id
+}
+
+// DeleteVertexArray implements Renderer DeleteVertexArray method
+func (renderer *nullRenderer) DeleteVertexArray(vao uint32) {
+	delete(renderer.vertexArrays, vao)
+}
+
+// SetVertexAttribute implements Renderer SetVertexAttribute method
+func (renderer *nullRenderer) SetVertexAttribute(vao uint32, attribute VertexAttribute) error {
+	va, ok := renderer.vertexArrays[vao]
+	if !ok {
+		return fmt.Errorf("renderer: vertex array %d not found", vao)
+	}
+	var data = reflect.ValueOf(attribute.Data)
+	switch data.Kind() {
+	case reflect.Slice:
+		switch data.Type().Elem().Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Uint8, reflect.Uint16, reflect.Uint32,
+			reflect.Float32, reflect.Float64:
+		default:
+			return fmt.Errorf("renderer: unsupported vertex attribute data %T", attribute.Data)
+		}
+	default:
+		return fmt.Errorf("renderer: unsupported vertex attribute data %T", attribute.Data)
+	}
+	if attribute.Size < 1 || attribute.Size > 4 {
+		return fmt.Errorf("renderer: invalid vertex attribute size %d", attribute.Size)
+	}
+	va.buffers[attribute.Location] = data.Len() * int(data.Type().Elem().Size())
+	return nil
+}
+
+// SetIndices implements Renderer SetIndices method
+func (renderer *nullRenderer) SetIndices(vao uint32, indices []uint32) {
+	if va, ok := renderer.vertexArrays[vao]; ok {
+		va.indices = len(indices) * 4
+	}
+}
+
+// Draw implements Renderer Draw method
+func (renderer *nullRenderer) Draw(vao uint32, mode DrawMode, first, count int) {
+	if _, ok := renderer.vertexArrays[vao]; !ok || count <= 0 {
+		return
+	}
+	var triangles, lines, points = mode.primitives(count)
+	renderer.stats.DrawCalls++
+	renderer.stats.Triangles += triangles
+	renderer.stats.Lines += lines
+	renderer.stats.Points += points
+}
+
+// CreateRenderTarget implements Renderer CreateRenderTarget method
+func (renderer *nullRenderer) CreateRenderTarget(width, height int) (RenderTarget, error) {
+	var target = RenderTarget{
+		Id:      renderer.newId(),
+		Texture: renderer.newId(),
+		Depth:   renderer.newId(),
+		Width:   width,
+		Height:  height,
+	}
+	renderer.renderTargets[target.Id] = target
+	return target, nil
+}
+
+// DeleteRenderTarget implements Renderer DeleteRenderTarget method
+func (renderer *nullRenderer) DeleteRenderTarget(target RenderTarget) {
+	if renderer.renderTarget.Id == target.Id {
+		renderer.renderTarget = RenderTarget{}
+	}
+	delete(renderer.renderTargets, target.Id)
+}
+
+// BindRenderTarget implements Renderer BindRenderTarget method
+func (renderer *nullRenderer) BindRenderTarget(target RenderTarget) {
+	renderer.renderTarget = target
+}
+
+// DrawTexture implements Renderer DrawTexture method
+func (renderer *nullRenderer) DrawTexture(texture uint32, opacity float32, offsetX, offsetY float32) error {
+	// blits are not counted in stats like the OpenGL renderer
+	return nil
+}
+
+// ReadPixels implements Renderer ReadPixels method, the image is blank
+func (renderer *nullRenderer) ReadPixels(target RenderTarget) (*image.RGBA, error) {
+	var w, h = target.Width, target.Height
+	if target.IsDefault() {
+		w, h = int(renderer.viewport[2]), int(renderer.viewport[3])
+	}
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("renderer: invalid read pixels size %dx%d", w, h)
+	}
+	return image.NewRGBA(image.Rect(0, 0, w, h)), nil
+}
+
+// BeginFrame implements Renderer BeginFrame method
+func (renderer *nullRenderer) BeginFrame() {
+	renderer.stats = Stats{}
+}
+
+// EndFrame implements Renderer EndFrame method
+func (renderer *nullRenderer) EndFrame() {}
+
+// Stats implements Renderer Stats method
+func (renderer *nullRenderer) Stats() Stats {
+	var stats = renderer.stats
+	stats.Programs = len(renderer.programIds)
+	for _, va := range renderer.vertexArrays {
+		for _, bytes := range va.buffers {
+			stats.Buffers++
+			stats.BufferBytes += bytes
+		}
+		if va.indices > 0 {
+			stats.Buffers++
+			stats.BufferBytes += va.indices
+		}
+	}
+	for _, bytes := range renderer.uniformBlocks {
+		stats.Buffers++
+		stats.BufferBytes += bytes
+	}
+	for _, target := range renderer.renderTargets {
+		stats.Textures++
+		stats.TextureBytes += target.Width * target.Height * renderTargetPixelBytes
+	}
+	return stats
+}
+
+// SetGPUTimer implements Renderer SetGPUTimer method, GPU time is always zero
+func (renderer *nullRenderer) SetGPUTimer(enabled bool) {}
@@ -1,75 +1,330 @@
 package renderer
 
 import (
-	"errors"
-	"fmt"
-	"unsafe"
-
 	"github.com/go-gl/gl/v3.3-core/gl"
-	"github.com/gopherd/doge/math/tensor"
 
+	"github.com/gopherd/three/core"
 	"github.com/gopherd/three/driver/renderer/shader"
 )
 
 type openglRenderer struct {
+	state          State
+	stateValid     bool
+	programs       programCache
+	programInfos   map[uint32]*programInfo
+	uniformBlocks  map[string]*uniformBlock
+	vertexArrays   map[uint32]*vertexArray
+	currentProgram uint32
+	renderTargets  map[uint32]RenderTarget
+	renderTarget   RenderTarget // renderTarget is the bound render target
+	viewport       [4]int32     // viewport of the default framebuffer
+	scissor        [4]int32     // scissor is the scissor rectangle, disabled if empty
+	blit           struct {
+		program Program
+		vao     uint32
+	}
+	stats    Stats
+	gpuTimer gpuTimer
+
+	// maxUniformBufferBindings is GL_MAX_UNIFORM_BUFFER_BINDINGS, it's
+	// queried on allocating the first uniform block
+	maxUniformBufferBindings int32
+
+	// offscreen makes the default framebuffer an offscreen render target,
+	// defaultTarget is the target created by Init
+	offscreen     bool
+	defaultTarget RenderTarget
 }
 
 func OpenGLRenderer() Renderer {
 	return &openglRenderer{}
 }
 
-func (openglRenderer) Init(width, height int) error {
+// OffscreenRenderer creates an OpenGL Renderer whose default framebuffer is
+// an offscreen render target of the size passed to Init, e.g. for batch
+// rendering with a hidden window, see window.Offscreen. Frames are read
+// back by ReadPixels.
+func OffscreenRenderer() Renderer {
+	return &openglRenderer{offscreen: true}
+}
+
+func (r *openglRenderer) Init(width, height int) error {
 	if err := gl.Init(); err != nil {
 		return err
 	}
-	gl.Viewport(0, 0, int32(width), int32(height))
+	var debugOutput = enableDebugOutput()
+	core.LogRenderer.Info("OpenGL initialized",
+		"version", gl.GoStr(gl.GetString(gl.VERSION)),
+		"renderer", gl.GoStr(gl.GetString(gl.RENDERER)),
+		"debugOutput", debugOutput,
+	)
+	r.Viewport(0, 0, int32(width), int32(height))
+	r.stateValid = false
+	r.SetState(DefaultState())
+	if r.offscreen {
+		target, err := r.CreateRenderTarget(width, height)
+		if err != nil {
+			return err
+		}
+		r.defaultTarget = target
+		r.BindRenderTarget(RenderTarget{})
+	}
 	return nil
 }
 
-func (openglRenderer) Viewport(x, y, w, h int32) {
-	gl.Viewport(x, y, w, h)
+// Viewport implements Renderer Viewport method, the viewport of default
+// framebuffer is restored when it's bound again if a render target bound
+func (renderer *openglRenderer) Viewport(x, y, w, h int32) {
+	renderer.viewport = [4]int32{x, y, w, h}
+	if renderer.renderTarget.IsDefault() {
+		gl.Viewport(x, y, w, h)
+	}
 }
 
-func (openglRenderer) ClearColor(r, g, b, a float32) {
-	gl.ClearColor(r, g, b, a)
-	gl.Clear(gl.COLOR_BUFFER_BIT)
+// GetViewport implements Renderer GetViewport method
+func (renderer *openglRenderer) GetViewport() (x, y, w, h int32) {
+	var v = renderer.viewport
+	return v[0], v[1], v[2], v[3]
 }
 
-func createShader(shaderType uint32, source string) (uint32, error) {
-	var success int32
+// ClearColor implements Renderer ClearColor method
+func (renderer *openglRenderer) ClearColor(r, g, b, a float32) {
+	renderer.Clear(ClearAll, r, g, b, a)
+}
+
+// Clear implements Renderer Clear method
+func (renderer *openglRenderer) Clear(mask ClearMask, r, g, b, a float32) {
+	var bits uint32
+	if mask&ClearColorBuffer != 0 {
+		gl.ClearColor(r, g, b, a)
+		bits |= gl.COLOR_BUFFER_BIT
+	}
+	if mask&ClearDepthBuffer != 0 {
+		// depth buffer can't be cleared while depth write is disabled
+		if renderer.stateValid && !renderer.state.Depth.Write {
+			renderer.state.Depth.Write = true
+			gl.DepthMask(true)
+		}
+		bits |= gl.DEPTH_BUFFER_BIT
+	}
+	if mask&ClearStencilBuffer != 0 {
+		bits |= gl.STENCIL_BUFFER_BIT
+	}
+	if bits != 0 {
+		gl.Clear(bits)
+	}
+}
+
+// Scissor implements Renderer Scissor method
+func (renderer *openglRenderer) Scissor(x, y, w, h int32) {
+	if w <= 0 || h <= 0 {
+		x, y, w, h = 0, 0, 0, 0
+	}
+	var scissor = [4]int32{x, y, w, h}
+	if scissor == renderer.scissor {
+		return
+	}
+	var enabled = renderer.scissor[2] > 0
+	renderer.scissor = scissor
+	if w == 0 {
+		gl.Disable(gl.SCISSOR_TEST)
+		return
+	}
+	if !enabled {
+		gl.Enable(gl.SCISSOR_TEST)
+	}
+	gl.Scissor(x, y, w, h)
+}
+
+// SetState implements Renderer SetState method, only changed states are
+// submitted to OpenGL
+func (renderer *openglRenderer) SetState(state State) {
+	var old, force = renderer.state, !renderer.stateValid
+	if !force && old == state {
+		return
+	}
+	renderer.state = state
+	renderer.stateValid = true
+	renderer.stats.StateChanges++
+
+	if force || old.CullFace != state.CullFace {
+		switch state.CullFace {
+		case CullNone:
+			gl.Disable(gl.CULL_FACE)
+		case CullFront:
+			if force || old.CullFace == CullNone {
+				gl.Enable(gl.CULL_FACE)
+			}
+			gl.CullFace(gl.FRONT)
+		default:
+			if force || old.CullFace == CullNone {
+				gl.Enable(gl.CULL_FACE)
+			}
+			gl.CullFace(gl.BACK)
+		}
+	}
+
+	if force || old.Blend.Enabled != state.Blend.Enabled {
+		if state.Blend.Enabled {
+			gl.Enable(gl.BLEND)
+		} else {
+			gl.Disable(gl.BLEND)
+		}
+	}
+	if state.Blend.Enabled {
+		if force || !old.Blend.Enabled ||
+			old.Blend.Equation != state.Blend.Equation ||
+			old.Blend.EquationAlpha != state.Blend.EquationAlpha {
+			gl.BlendEquationSeparate(
+				glBlendEquation(state.Blend.Equation),
+				glBlendEquation(state.Blend.EquationAlpha),
+			)
+		}
+		if force || !old.Blend.Enabled ||
+			old.Blend.Src != state.Blend.Src ||
+			old.Blend.Dst != state.Blend.Dst ||
+			old.Blend.SrcAlpha != state.Blend.SrcAlpha ||
+			old.Blend.DstAlpha != state.Blend.DstAlpha {
+			gl.BlendFuncSeparate(
+				glBlendFactor(state.Blend.Src),
+				glBlendFactor(state.Blend.Dst),
+				glBlendFactor(state.Blend.SrcAlpha),
+				glBlendFactor(state.Blend.DstAlpha),
+			)
+		}
+	}
+
+	if force || old.Depth.Test != state.Depth.Test {
+		if state.Depth.Test {
+			gl.Enable(gl.DEPTH_TEST)
+		} else {
+			gl.Disable(gl.DEPTH_TEST)
+		}
+	}
+	if force || old.Depth.Write != state.Depth.Write {
+		gl.DepthMask(state.Depth.Write)
+	}
+	if force || old.Depth.Func != state.Depth.Func {
+		gl.DepthFunc(glDepthFunc(state.Depth.Func))
+	}
+}
+
+func glBlendEquation(equation BlendEquation) uint32 {
+	switch equation {
+	case BlendSubtract:
+		return gl.FUNC_SUBTRACT
+	case BlendReverseSubtract:
+		return gl.FUNC_REVERSE_SUBTRACT
+	case BlendMin:
+		return gl.MIN
+	case BlendMax:
+		return gl.MAX
+	default:
+		return gl.FUNC_ADD
+	}
+}
+
+func glBlendFactor(factor BlendFactor) uint32 {
+	switch factor {
+	case BlendOne:
+		return gl.ONE
+	case BlendSrcColor:
+		return gl.SRC_COLOR
+	case BlendOneMinusSrcColor:
+		return gl.ONE_MINUS_SRC_COLOR
+	case BlendSrcAlpha:
+		return gl.SRC_ALPHA
+	case BlendOneMinusSrcAlpha:
+		return gl.ONE_MINUS_SRC_ALPHA
+	case BlendDstAlpha:
+		return gl.DST_ALPHA
+	case BlendOneMinusDstAlpha:
+		return gl.ONE_MINUS_DST_ALPHA
+	case BlendDstColor:
+		return gl.DST_COLOR
+	case BlendOneMinusDstColor:
+		return gl.ONE_MINUS_DST_COLOR
+	case BlendSrcAlphaSaturate:
+		return gl.SRC_ALPHA_SATURATE
+	default:
+		return gl.ZERO
+	}
+}
+
+func glDepthFunc(fn DepthFunc) uint32 {
+	switch fn {
+	case DepthLess:
+		return gl.LESS
+	case DepthEqual:
+		return gl.EQUAL
+	case DepthGreaterEqual:
+		return gl.GEQUAL
+	case DepthGreater:
+		return gl.GREATER
+	case DepthNotEqual:
+		return gl.NOTEQUAL
+	case DepthAlways:
+		return gl.ALWAYS
+	case DepthNever:
+		return gl.NEVER
+	default:
+		return gl.LEQUAL
+	}
+}
+
+func createShader(stage shader.Stage, shaderType uint32, source string) (uint32, error) {
 	var shaderId = gl.CreateShader(shaderType)
-	var ptr = (*byte)(unsafe.Pointer(&source))
-	gl.ShaderSource(shaderId, 1, &ptr, nil)
+	var csource, free = gl.Strs(source + "\x00")
+	gl.ShaderSource(shaderId, 1, csource, nil)
+	free()
 	gl.CompileShader(shaderId)
+
+	var success int32
 	gl.GetShaderiv(shaderId, gl.COMPILE_STATUS, &success)
-	if success == 0 {
+	if success != gl.FALSE {
 		return shaderId, nil
 	}
-	const size = 512
-	var buf = make([]byte, size)
-	var n int32
-	gl.GetShaderInfoLog(shaderId, size, &n, &buf[0])
-	return 0, errors.New(string(buf[:n]))
+	var length int32
+	gl.GetShaderiv(shaderId, gl.INFO_LOG_LENGTH, &length)
+	var log = make([]byte, length+1)
+	gl.GetShaderInfoLog(shaderId, length+1, nil, &log[0])
+	gl.DeleteShader(shaderId)
+	return 0, shader.ParseLog(stage, gl.GoStr(&log[0]), source)
 }
 
-func (openglRenderer) CreateProgram(vshader, fshader string) (program Program, err error) {
-	var (
-		vshaderId uint32
-		fshaderId uint32
-	)
-	vshaderId, err = createShader(gl.VERTEX_SHADER, vshader)
+// CreateProgram implements Renderer CreateProgram method, shaders are
+// compiled and linked into the returned program
+func (renderer *openglRenderer) CreateProgram(vshader, fshader string) (program Program, err error) {
+	vshaderId, err := createShader(shader.VertexStage, gl.VERTEX_SHADER, vshader)
 	if err != nil {
 		return
 	}
-	fshaderId, err = createShader(gl.FRAGMENT_SHADER, fshader)
+	fshaderId, err := createShader(shader.FragmentStage, gl.FRAGMENT_SHADER, fshader)
 	if err != nil {
+		gl.DeleteShader(vshaderId)
 		return
 	}
 	var id = gl.CreateProgram()
 	gl.AttachShader(id, vshaderId)
 	gl.AttachShader(id, fshaderId)
+	err = renderer.LinkProgram(id)
+	gl.DetachShader(id, vshaderId)
+	gl.DetachShader(id, fshaderId)
 	gl.DeleteShader(vshaderId)
 	gl.DeleteShader(fshaderId)
+	if err != nil {
+		gl.DeleteProgram(id)
+		return
+	}
+	if renderer.programInfos == nil {
+		renderer.programInfos = make(map[uint32]*programInfo)
+	}
+	var info = introspectProgram(id)
+	if err = renderer.bindUniformBlocks(id, info); err != nil {
+		gl.DeleteProgram(id)
+		return
+	}
+	renderer.programInfos[id] = info
 	program = Program{
 		Id:               id,
 		VertextShaderId:  vshaderId,
@@ -78,128 +333,36 @@ func (openglRenderer) CreateProgram(vshader, fshader string) (program Program, e
 	return
 }
 
-func (openglRenderer) ClearProgram(program Program) {
+func (renderer *openglRenderer) ClearProgram(program Program) {
+	if renderer.currentProgram == program.Id {
+		renderer.currentProgram = 0
+		gl.UseProgram(0)
+	}
+	delete(renderer.programInfos, program.Id)
 	gl.DeleteProgram(program.Id)
 }
 
+// AcquireProgram implements Renderer AcquireProgram method
+func (renderer *openglRenderer) AcquireProgram(source shader.Shader) (Program, error) {
+	return renderer.programs.acquire(renderer, shader.GLSL330, source)
+}
+
+// ReleaseProgram implements Renderer ReleaseProgram method
+func (renderer *openglRenderer) ReleaseProgram(program Program) {
+	renderer.programs.release(renderer, program)
+}
+
+// LinkProgram implements Renderer LinkProgram method
 func (openglRenderer) LinkProgram(program uint32) error {
 	gl.LinkProgram(program)
 	var success int32
 	gl.GetProgramiv(program, gl.LINK_STATUS, &success)
-	if success == 0 {
+	if success != gl.FALSE {
 		return nil
 	}
-	const size = 512
-	var buf = make([]byte, size)
-	var n int32
-	gl.GetProgramInfoLog(program, size, &n, &buf[0])
-	return errors.New(string(buf[:n]))
-}
-
-func (openglRenderer) SetUniform(program uint32, name string, uniform shader.Uniform) {
-	var location = gl.GetUniformLocation(program, (*byte)(unsafe.Pointer(&name)))
-	switch value := uniform.(type) {
-	case int:
-		gl.Uniform1i(location, int32(value))
-	case [2]int:
-		gl.Uniform2i(location, int32(value[0]), int32(value[1]))
-	case [3]int:
-		gl.Uniform3i(location, int32(value[0]), int32(value[1]), int32(value[2]))
-	case [4]int:
-		gl.Uniform4i(location, int32(value[0]), int32(value[1]), int32(value[2]), int32(value[3]))
-	case int8:
-		gl.Uniform1i(location, int32(value))
-	case [2]int8:
-		gl.Uniform2i(location, int32(value[0]), int32(value[1]))
-	case [3]int8:
-		gl.Uniform3i(location, int32(value[0]), int32(value[1]), int32(value[2]))
-	case [4]int8:
-		gl.Uniform4i(location, int32(value[0]), int32(value[1]), int32(value[2]), int32(value[3]))
-	case int16:
-		gl.Uniform1i(location, int32(value))
-	case [2]int16:
-		gl.Uniform2i(location, int32(value[0]), int32(value[1]))
-	case [3]int16:
-		gl.Uniform3i(location, int32(value[0]), int32(value[1]), int32(value[2]))
-	case [4]int16:
-		gl.Uniform4i(location, int32(value[0]), int32(value[1]), int32(value[2]), int32(value[3]))
-	case int32:
-		gl.Uniform1i(location, int32(value))
-	case [2]int32:
-		gl.Uniform2i(location, int32(value[0]), int32(value[1]))
-	case [3]int32:
-		gl.Uniform3i(location, int32(value[0]), int32(value[1]), int32(value[2]))
-	case [4]int32:
-		gl.Uniform4i(location, int32(value[0]), int32(value[1]), int32(value[2]), int32(value[3]))
-	case uint:
-		gl.Uniform1ui(location, uint32(value))
-	case [2]uint:
-		gl.Uniform2ui(location, uint32(value[0]), uint32(value[1]))
-	case [3]uint:
-		gl.Uniform3ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]))
-	case [4]uint:
-		gl.Uniform4ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]), uint32(value[3]))
-	case uint8:
-		gl.Uniform1ui(location, uint32(value))
-	case [2]uint8:
-		gl.Uniform2ui(location, uint32(value[0]), uint32(value[1]))
-	case [3]uint8:
-		gl.Uniform3ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]))
-	case [4]uint8:
-		gl.Uniform4ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]), uint32(value[3]))
-	case uint16:
-		gl.Uniform1ui(location, uint32(value))
-	case [2]uint16:
-		gl.Uniform2ui(location, uint32(value[0]), uint32(value[1]))
-	case [3]uint16:
-		gl.Uniform3ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]))
-	case [4]uint16:
-		gl.Uniform4ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]), uint32(value[3]))
-	case uint32:
-		gl.Uniform1ui(location, uint32(value))
-	case [2]uint32:
-		gl.Uniform2ui(location, uint32(value[0]), uint32(value[1]))
-	case [3]uint32:
-		gl.Uniform3ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]))
-	case [4]uint32:
-		gl.Uniform4ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]), uint32(value[3]))
-	case float32:
-		gl.Uniform1f(location, value)
-	case [2]float32:
-		gl.Uniform2f(location, value[0], value[1])
-	case tensor.Vector2[float32]:
-		gl.Uniform2f(location, value.X(), value.Y())
-	case [3]float32:
-		gl.Uniform3f(location, value[0], value[1], value[2])
-	case tensor.Vector3[float32]:
-		gl.Uniform3f(location, value.X(), value.Y(), value.Z())
-	case [4]float32:
-		gl.Uniform4f(location, value[0], value[1], value[2], value[3])
-	case tensor.Vector4[float32]:
-		gl.Uniform4f(location, value.X(), value.Y(), value.Z(), value.W())
-	case float64:
-		gl.Uniform1d(location, value)
-	case [2]float64:
-		gl.Uniform2d(location, value[0], value[1])
-	case tensor.Vector2[float64]:
-		gl.Uniform2d(location, value.X(), value.Y())
-	case [3]float64:
-		gl.Uniform3d(location, value[0], value[1], value[2])
-	case tensor.Vector3[float64]:
-		gl.Uniform3d(location, value.X(), value.Y(), value.Z())
-	case [4]float64:
-		gl.Uniform4d(location, value[0], value[1], value[2], value[3])
-	case tensor.Vector4[float64]:
-		gl.Uniform4d(location, value.X(), value.Y(), value.Z(), value.W())
-	case tensor.Matrix3[float32]:
-		gl.UniformMatrix3fv(location, 1, false, &value[0])
-	case tensor.Matrix3[float64]:
-		gl.UniformMatrix3dv(location, 1, false, &value[0])
-	case tensor.Matrix4[float32]:
-		gl.UniformMatrix4fv(location, 1, false, &value[0])
-	case tensor.Matrix4[float64]:
-		gl.UniformMatrix4dv(location, 1, false, &value[0])
-	default:
-		panic(fmt.Sprintf("unsupported uniform type: %T", uniform))
-	}
+	var length int32
+	gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &length)
+	var log = make([]byte, length+1)
+	gl.GetProgramInfoLog(program, length+1, nil, &log[0])
+	return shader.ParseLog(shader.LinkStage, gl.GoStr(&log[0]), "")
 }
@@ -0,0 +1,104 @@
+package renderer
+
+import (
+	"unsafe"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+
+	"github.com/gopherd/three/core"
+)
+
+// enableDebugOutput forwards GL debug messages to the logger if the
+// context is a debug context created by window.Options.GLDebug, and
+// KHR_debug is available, i.e. OpenGL 4.3 or the extension is supported
+func enableDebugOutput() bool {
+	if !isDebugContext() || !hasDebugOutput() {
+		return false
+	}
+	gl.Enable(gl.DEBUG_OUTPUT)
+	// messages are delivered on the calling thread so the logger sees
+	// them in order with the calls causing them
+	gl.Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+	gl.DebugMessageCallback(onDebugMessage, nil)
+	return true
+}
+
+// isDebugContext reports whether the current context is a debug context,
+// synchronous debug output is too slow for release builds
+func isDebugContext() bool {
+	var flags int32
+	gl.GetIntegerv(gl.CONTEXT_FLAGS, &flags)
+	return flags&gl.CONTEXT_FLAG_DEBUG_BIT != 0
+}
+
+func hasDebugOutput() bool {
+	var major, minor int32
+	gl.GetIntegerv(gl.MAJOR_VERSION, &major)
+	gl.GetIntegerv(gl.MINOR_VERSION, &minor)
+	if major > 4 || (major == 4 && minor >= 3) {
+		return true
+	}
+	var n int32
+	gl.GetIntegerv(gl.NUM_EXTENSIONS, &n)
+	for i := int32(0); i < n; i++ {
+		if gl.GoStr(gl.GetStringi(gl.EXTENSIONS, uint32(i))) == "GL_KHR_debug" {
+			return true
+		}
+	}
+	return false
+}
+
+func onDebugMessage(source, typ, id, severity uint32, length int32, message string, userParam unsafe.Pointer) {
+	var category = core.LogRenderer
+	if source == gl.DEBUG_SOURCE_SHADER_COMPILER {
+		category = core.LogShader
+	}
+	var level core.LogLevel
+	switch severity {
+	case gl.DEBUG_SEVERITY_HIGH:
+		level = core.LevelError
+	case gl.DEBUG_SEVERITY_MEDIUM:
+		level = core.LevelWarn
+	case gl.DEBUG_SEVERITY_LOW:
+		level = core.LevelInfo
+	default:
+		level = core.LevelDebug
+	}
+	category.Log(level, message, "source", debugSourceName(source), "type", debugTypeName(typ), "id", id)
+}
+
+func debugSourceName(source uint32) string {
+	switch source {
+	case gl.DEBUG_SOURCE_API:
+		return "api"
+	case gl.DEBUG_SOURCE_WINDOW_SYSTEM:
+		return "window_system"
+	case gl.DEBUG_SOURCE_SHADER_COMPILER:
+		return "shader_compiler"
+	case gl.DEBUG_SOURCE_THIRD_PARTY:
+		return "third_party"
+	case gl.DEBUG_SOURCE_APPLICATION:
+		return "application"
+	default:
+		return "other"
+	}
+}
+
+func debugTypeName(typ uint32) string {
+	switch typ {
+	case gl.DEBUG_TYPE_ERROR:
+		return "error"
+	case gl.DEBUG_TYPE_DEPRECATED_BEHAVIOR:
+		return "deprecated"
+	case gl.DEBUG_TYPE_UNDEFINED_BEHAVIOR:
+		return "undefined"
+	case gl.DEBUG_TYPE_PORTABILITY:
+		return "portability"
+	case gl.DEBUG_TYPE_PERFORMANCE:
+		return "performance"
+	case gl.DEBUG_TYPE_MARKER:
+		return "marker"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,197 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+
+	"github.com/gopherd/three/core"
+)
+
+// CreateRenderTarget implements Renderer CreateRenderTarget method
+func (renderer *openglRenderer) CreateRenderTarget(width, height int) (RenderTarget, error) {
+	var target = RenderTarget{Width: width, Height: height}
+	if width <= 0 || height <= 0 {
+		return target, fmt.Errorf("renderer: invalid render target size %dx%d", width, height)
+	}
+	gl.GenFramebuffers(1, &target.Id)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, target.Id)
+
+	gl.GenTextures(1, &target.Texture)
+	gl.BindTexture(gl.TEXTURE_2D, target.Texture)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(width), int32(height), 0, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, target.Texture, 0)
+
+	gl.GenRenderbuffers(1, &target.Depth)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, target.Depth)
+	gl.RenderbufferStorage(gl.RENDERBUFFER, gl.DEPTH24_STENCIL8, int32(width), int32(height))
+	gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, gl.RENDERBUFFER, target.Depth)
+
+	var status = gl.CheckFramebufferStatus(gl.FRAMEBUFFER)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, renderer.framebuffer(renderer.renderTarget))
+	if status != gl.FRAMEBUFFER_COMPLETE {
+		renderer.deleteRenderTarget(target)
+		return RenderTarget{}, fmt.Errorf("renderer: incomplete framebuffer 0x%x", status)
+	}
+	if renderer.renderTargets == nil {
+		renderer.renderTargets = make(map[uint32]RenderTarget)
+	}
+	renderer.renderTargets[target.Id] = target
+	core.LogResource.Debug("render target created", "id", target.Id, "width", width, "height", height)
+	return target, nil
+}
+
+// DeleteRenderTarget implements Renderer DeleteRenderTarget method
+func (renderer *openglRenderer) DeleteRenderTarget(target RenderTarget) {
+	if target.IsDefault() {
+		return
+	}
+	if renderer.renderTarget.Id == target.Id {
+		renderer.BindRenderTarget(RenderTarget{})
+	}
+	delete(renderer.renderTargets, target.Id)
+	renderer.deleteRenderTarget(target)
+	core.LogResource.Debug("render target deleted", "id", target.Id)
+}
+
+func (renderer *openglRenderer) deleteRenderTarget(target RenderTarget) {
+	gl.DeleteFramebuffers(1, &target.Id)
+	gl.DeleteTextures(1, &target.Texture)
+	gl.DeleteRenderbuffers(1, &target.Depth)
+}
+
+// BindRenderTarget implements Renderer BindRenderTarget method
+func (renderer *openglRenderer) BindRenderTarget(target RenderTarget) {
+	renderer.renderTarget = target
+	gl.BindFramebuffer(gl.FRAMEBUFFER, renderer.framebuffer(target))
+	if target.IsDefault() {
+		var v = renderer.viewport
+		gl.Viewport(v[0], v[1], v[2], v[3])
+	} else {
+		gl.Viewport(0, 0, int32(target.Width), int32(target.Height))
+	}
+}
+
+// framebuffer returns id of framebuffer of the target, the default
+// framebuffer of OffscreenRenderer is its offscreen target
+func (renderer *openglRenderer) framebuffer(target RenderTarget) uint32 {
+	if target.IsDefault() {
+		return renderer.defaultTarget.Id
+	}
+	return target.Id
+}
+
+// ReadPixels implements Renderer ReadPixels method
+func (renderer *openglRenderer) ReadPixels(target RenderTarget) (*image.RGBA, error) {
+	var x, y, w, h = int32(0), int32(0), int32(target.Width), int32(target.Height)
+	if target.IsDefault() {
+		x, y, w, h = renderer.GetViewport()
+	}
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("renderer: invalid read pixels size %dx%d", w, h)
+	}
+	var img = image.NewRGBA(image.Rect(0, 0, int(w), int(h)))
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, renderer.framebuffer(target))
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	gl.ReadPixels(x, y, w, h, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, renderer.framebuffer(renderer.renderTarget))
+	flipRows(img)
+	return img, nil
+}
+
+// flipRows flips rows of img read by OpenGL bottom-up
+func flipRows(img *image.RGBA) {
+	var height = img.Rect.Dy()
+	var row = make([]byte, img.Stride)
+	for top, bottom := 0, height-1; top < bottom; top, bottom = top+1, bottom-1 {
+		var a = img.Pix[top*img.Stride : (top+1)*img.Stride]
+		var b = img.Pix[bottom*img.Stride : (bottom+1)*img.Stride]
+		copy(row, a)
+		copy(a, b)
+		copy(b, row)
+	}
+}
+
+const blitVertexShader = `#version 330 core
+layout(location = 0) in vec2 position;
+uniform vec2 offset;
+out vec2 vUv;
+void main() {
+	vUv = position * 0.5 + 0.5;
+	gl_Position = vec4(position + offset * 2.0, 0.0, 1.0);
+}
+`
+
+const blitFragmentShader = `#version 330 core
+uniform sampler2D map;
+uniform float opacity;
+in vec2 vUv;
+out vec4 fragColor;
+void main() {
+	fragColor = vec4(texture(map, vUv).rgb, opacity);
+}
+`
+
+// blitState blends textures over the framebuffer by opacity without depth
+// testing, alpha of textures is ignored since render targets of scenes may
+// be cleared with transparent background
+var blitState = State{
+	CullFace: CullNone,
+	Blend: BlendState{
+		Enabled:  true,
+		Src:      BlendSrcAlpha,
+		Dst:      BlendOneMinusSrcAlpha,
+		SrcAlpha: BlendOne,
+		DstAlpha: BlendOneMinusSrcAlpha,
+	},
+	Depth: DepthState{Func: DepthAlways},
+}
+
+// DrawTexture implements Renderer DrawTexture method
+func (renderer *openglRenderer) DrawTexture(texture uint32, opacity float32, offsetX, offsetY float32) error {
+	// the internal blit program is not counted in stats
+	var stats = renderer.stats
+	defer func() { renderer.stats = stats }()
+	if renderer.blit.vao == 0 {
+		program, err := renderer.CreateProgram(blitVertexShader, blitFragmentShader)
+		if err != nil {
+			return err
+		}
+		var vao = renderer.CreateVertexArray()
+		if err := renderer.SetVertexAttribute(vao, VertexAttribute{
+			Location: 0,
+			Size:     2,
+			Data:     []float32{-1, -1, 1, -1, -1, 1, 1, 1},
+		}); err != nil {
+			renderer.DeleteVertexArray(vao)
+			renderer.ClearProgram(program)
+			return err
+		}
+		renderer.blit.program = program
+		renderer.blit.vao = vao
+	}
+	var program = renderer.blit.program.Id
+	renderer.SetState(blitState)
+	renderer.UseProgram(program)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, texture)
+	if err := renderer.SetUniform(program, "map", int32(0)); err != nil {
+		return err
+	}
+	if err := renderer.SetUniform(program, "opacity", opacity); err != nil {
+		return err
+	}
+	if err := renderer.SetUniform(program, "offset", [2]float32{offsetX, offsetY}); err != nil {
+		return err
+	}
+	renderer.Draw(renderer.blit.vao, TriangleStrip, 0, 4)
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+	return nil
+}
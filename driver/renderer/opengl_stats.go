@@ -0,0 +1,113 @@
+package renderer
+
+import (
+	"time"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+const gpuTimerQueries = 4
+
+type gpuTimer struct {
+	enabled bool
+	queries [gpuTimerQueries]uint32
+	pending [gpuTimerQueries]bool
+	index   int
+	active  bool // active reports whether a query of current frame began
+	elapsed time.Duration
+}
+
+// SetGPUTimer implements Renderer SetGPUTimer method
+func (renderer *openglRenderer) SetGPUTimer(enabled bool) {
+	var timer = &renderer.gpuTimer
+	if timer.enabled == enabled {
+		return
+	}
+	timer.enabled = enabled
+	if enabled {
+		gl.GenQueries(gpuTimerQueries, &timer.queries[0])
+	} else {
+		if timer.active {
+			gl.EndQuery(gl.TIME_ELAPSED)
+		}
+		gl.DeleteQueries(gpuTimerQueries, &timer.queries[0])
+		*timer = gpuTimer{}
+	}
+}
+
+// BeginFrame implements Renderer BeginFrame method
+func (renderer *openglRenderer) BeginFrame() {
+	renderer.stats = Stats{}
+	var timer = &renderer.gpuTimer
+	if !timer.enabled {
+		return
+	}
+	// collect results of finished queries without stalling the pipeline
+	for i := 1; i <= gpuTimerQueries; i++ {
+		var index = (timer.index + i) % gpuTimerQueries
+		if !timer.pending[index] {
+			continue
+		}
+		var available int32
+		gl.GetQueryObjectiv(timer.queries[index], gl.QUERY_RESULT_AVAILABLE, &available)
+		if available == gl.FALSE {
+			continue
+		}
+		var elapsed uint64
+		gl.GetQueryObjectui64v(timer.queries[index], gl.QUERY_RESULT, &elapsed)
+		timer.elapsed = time.Duration(elapsed)
+		timer.pending[index] = false
+	}
+	if timer.pending[timer.index] {
+		// no free query, skip timing this frame
+		return
+	}
+	gl.BeginQuery(gl.TIME_ELAPSED, timer.queries[timer.index])
+	timer.pending[timer.index] = true
+	timer.active = true
+}
+
+// EndFrame implements Renderer EndFrame method
+func (renderer *openglRenderer) EndFrame() {
+	var timer = &renderer.gpuTimer
+	if !timer.active {
+		return
+	}
+	timer.active = false
+	gl.EndQuery(gl.TIME_ELAPSED)
+	timer.index = (timer.index + 1) % gpuTimerQueries
+}
+
+// Stats implements Renderer Stats method
+func (renderer *openglRenderer) Stats() Stats {
+	var stats = renderer.stats
+	stats.Programs = len(renderer.programInfos)
+	if renderer.blit.vao != 0 {
+		stats.Programs--
+	}
+	for vao, va := range renderer.vertexArrays {
+		if vao == renderer.blit.vao {
+			continue
+		}
+		for _, buffer := range va.buffers {
+			stats.Buffers++
+			stats.BufferBytes += buffer.bytes
+		}
+		if va.indices.id != 0 {
+			stats.Buffers++
+			stats.BufferBytes += va.indices.bytes
+		}
+	}
+	for _, block := range renderer.uniformBlocks {
+		if block.buffer != 0 {
+			stats.Buffers++
+			stats.BufferBytes += len(block.data)
+		}
+	}
+	for _, target := range renderer.renderTargets {
+		stats.Textures++
+		stats.TextureBytes += target.Width * target.Height * renderTargetPixelBytes
+	}
+	stats.GPUTime = renderer.gpuTimer.elapsed
+	return stats
+}
@@ -0,0 +1,558 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+	"github.com/gopherd/doge/math/tensor"
+	"github.com/gopherd/doge/operator"
+
+	"github.com/gopherd/three/driver/renderer/shader"
+)
+
+type uniformState struct {
+	info  UniformInfo
+	value shader.Uniform
+	set   bool
+}
+
+// programInfo holds active uniforms and attributes introspected once after
+// linking, and values of uniforms uploaded last time
+type programInfo struct {
+	uniforms      []UniformInfo
+	attributes    []AttributeInfo
+	blocks        []string
+	uniformStates map[string]*uniformState
+}
+
+// uniformBlock is an uniform buffer shared by all programs which declare
+// the block with the same name
+type uniformBlock struct {
+	buffer  uint32
+	binding uint32
+	data    []byte
+}
+
+func introspectProgram(program uint32) *programInfo {
+	var info = &programInfo{
+		uniformStates: make(map[string]*uniformState),
+	}
+	var count, maxLength int32
+	gl.GetProgramiv(program, gl.ACTIVE_UNIFORMS, &count)
+	gl.GetProgramiv(program, gl.ACTIVE_UNIFORM_MAX_LENGTH, &maxLength)
+	var buf = make([]byte, maxLength+1)
+	for i := int32(0); i < count; i++ {
+		var length, size int32
+		var xtype uint32
+		gl.GetActiveUniform(program, uint32(i), int32(len(buf)), &length, &size, &xtype, &buf[0])
+		var name = string(buf[:length])
+		var location = gl.GetUniformLocation(program, gl.Str(name+"\x00"))
+		if location < 0 {
+			// uniforms of uniform blocks have no location
+			continue
+		}
+		var uniform = UniformInfo{
+			Name:     strings.TrimSuffix(name, "[0]"),
+			Type:     glDataType(xtype),
+			Size:     int(size),
+			Location: location,
+		}
+		info.uniforms = append(info.uniforms, uniform)
+		info.uniformStates[uniform.Name] = &uniformState{info: uniform}
+	}
+	gl.GetProgramiv(program, gl.ACTIVE_ATTRIBUTES, &count)
+	gl.GetProgramiv(program, gl.ACTIVE_ATTRIBUTE_MAX_LENGTH, &maxLength)
+	buf = make([]byte, maxLength+1)
+	for i := int32(0); i < count; i++ {
+		var length, size int32
+		var xtype uint32
+		gl.GetActiveAttrib(program, uint32(i), int32(len(buf)), &length, &size, &xtype, &buf[0])
+		var name = string(buf[:length])
+		info.attributes = append(info.attributes, AttributeInfo{
+			Name:     name,
+			Type:     glDataType(xtype),
+			Size:     int(size),
+			Location: gl.GetAttribLocation(program, gl.Str(name+"\x00")),
+		})
+	}
+	gl.GetProgramiv(program, gl.ACTIVE_UNIFORM_BLOCKS, &count)
+	gl.GetProgramiv(program, gl.ACTIVE_UNIFORM_BLOCK_MAX_NAME_LENGTH, &maxLength)
+	buf = make([]byte, maxLength+1)
+	for i := int32(0); i < count; i++ {
+		var length int32
+		gl.GetActiveUniformBlockName(program, uint32(i), int32(len(buf)), &length, &buf[0])
+		info.blocks = append(info.blocks, string(buf[:length]))
+	}
+	return info
+}
+
+// bindUniformBlocks binds uniform blocks of the program to binding points
+// of shared uniform buffers
+func (renderer *openglRenderer) bindUniformBlocks(program uint32, info *programInfo) error {
+	for i, name := range info.blocks {
+		block, err := renderer.uniformBlock(name)
+		if err != nil {
+			return err
+		}
+		gl.UniformBlockBinding(program, uint32(i), block.binding)
+	}
+	return nil
+}
+
+// uniformBlock returns the uniform block by name, a binding point is
+// allocated for the new block
+func (renderer *openglRenderer) uniformBlock(name string) (*uniformBlock, error) {
+	if block, ok := renderer.uniformBlocks[name]; ok {
+		return block, nil
+	}
+	if renderer.maxUniformBufferBindings == 0 {
+		gl.GetIntegerv(gl.MAX_UNIFORM_BUFFER_BINDINGS, &renderer.maxUniformBufferBindings)
+	}
+	if len(renderer.uniformBlocks) >= int(renderer.maxUniformBufferBindings) {
+		return nil, fmt.Errorf("renderer: uniform block %s exceeds %d uniform buffer bindings", name, renderer.maxUniformBufferBindings)
+	}
+	if renderer.uniformBlocks == nil {
+		renderer.uniformBlocks = make(map[string]*uniformBlock)
+	}
+	var block = &uniformBlock{binding: uint32(len(renderer.uniformBlocks))}
+	renderer.uniformBlocks[name] = block
+	return block, nil
+}
+
+// SetUniformBlock implements Renderer SetUniformBlock method
+func (renderer *openglRenderer) SetUniformBlock(name string, value any) error {
+	data, err := EncodeStd140(value)
+	if err != nil {
+		return err
+	}
+	block, err := renderer.uniformBlock(name)
+	if err != nil {
+		return err
+	}
+	if block.buffer != 0 && bytes.Equal(block.data, data) {
+		return nil
+	}
+	if block.buffer == 0 {
+		gl.GenBuffers(1, &block.buffer)
+	}
+	gl.BindBuffer(gl.UNIFORM_BUFFER, block.buffer)
+	if len(block.data) != len(data) {
+		gl.BufferData(gl.UNIFORM_BUFFER, len(data), gl.Ptr(data), gl.DYNAMIC_DRAW)
+		gl.BindBufferBase(gl.UNIFORM_BUFFER, block.binding, block.buffer)
+	} else {
+		gl.BufferSubData(gl.UNIFORM_BUFFER, 0, len(data), gl.Ptr(data))
+	}
+	gl.BindBuffer(gl.UNIFORM_BUFFER, 0)
+	block.data = data
+	return nil
+}
+
+// UseProgram implements Renderer UseProgram method
+func (renderer *openglRenderer) UseProgram(program uint32) {
+	if renderer.currentProgram == program {
+		return
+	}
+	renderer.currentProgram = program
+	renderer.stats.ProgramBinds++
+	gl.UseProgram(program)
+}
+
+// Uniforms implements Renderer Uniforms method
+func (renderer *openglRenderer) Uniforms(program uint32) []UniformInfo {
+	if info, ok := renderer.programInfos[program]; ok {
+		return info.uniforms
+	}
+	return nil
+}
+
+// Attributes implements Renderer Attributes method
+func (renderer *openglRenderer) Attributes(program uint32) []AttributeInfo {
+	if info, ok := renderer.programInfos[program]; ok {
+		return info.attributes
+	}
+	return nil
+}
+
+// SetUniform implements Renderer SetUniform method
+func (renderer *openglRenderer) SetUniform(program uint32, name string, uniform shader.Uniform) error {
+	info, ok := renderer.programInfos[program]
+	if !ok {
+		return fmt.Errorf("renderer: program %d not found", program)
+	}
+	if uniform == nil {
+		return fmt.Errorf("renderer: uniform %s is nil", name)
+	}
+	var value = reflect.ValueOf(uniform)
+	if isStructUniform(value.Type()) {
+		return renderer.setStructUniform(program, name, value)
+	}
+	state, ok := info.uniformStates[name]
+	if !ok {
+		// inactive uniforms are ignored
+		return nil
+	}
+	// values of non-comparable types, e.g. slices, are always uploaded
+	var comparable = value.Type().Comparable()
+	if comparable && state.set && state.value == uniform {
+		return nil
+	}
+	var valueType, count = uniformTypeOf(uniform)
+	if !state.info.Type.Accepts(valueType) {
+		return fmt.Errorf("renderer: uniform %s of type %v can't be set by %T", name, state.info.Type, uniform)
+	}
+	if count > state.info.Size {
+		return fmt.Errorf("renderer: uniform %s has %d elements, but %d given", name, state.info.Size, count)
+	}
+	renderer.UseProgram(program)
+	if !uploadUniform(state.info.Location, uniform) {
+		return fmt.Errorf("renderer: unsupported uniform type %T", uniform)
+	}
+	state.value = uniform
+	state.set = comparable
+	if !comparable {
+		state.value = nil
+	}
+	return nil
+}
+
+// isStructUniform reports whether values of type t are set to GLSL struct
+// uniforms or arrays of struct
+func isStructUniform(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Array, reflect.Slice:
+		return t.Elem().Kind() == reflect.Struct
+	}
+	return false
+}
+
+// setStructUniform sets fields of struct value to uniforms `name.field',
+// and elements of array to `name[i]'. Field name is specified by tag
+// `uniform:"name"', or the field name with lower first letter by default
+func (renderer *openglRenderer) setStructUniform(program uint32, name string, value reflect.Value) error {
+	if value.Kind() != reflect.Struct {
+		for i := 0; i < value.Len(); i++ {
+			var element = fmt.Sprintf("%s[%d]", name, i)
+			if err := renderer.setStructUniform(program, element, value.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var t = value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		var field = t.Field(i)
+		var fieldName = field.Tag.Get("uniform")
+		if !field.IsExported() || fieldName == "-" {
+			continue
+		}
+		if fieldName == "" {
+			var r, size = utf8.DecodeRuneInString(field.Name)
+			fieldName = string(unicode.ToLower(r)) + field.Name[size:]
+		}
+		if err := renderer.SetUniform(program, name+"."+fieldName, value.Field(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uniformTypeOf returns GLSL type and number of elements of the value
+func uniformTypeOf(uniform shader.Uniform) (DataType, int) {
+	switch value := uniform.(type) {
+	case []int32:
+		return TypeInt, len(value)
+	case [][2]int32:
+		return TypeIVec2, len(value)
+	case [][3]int32:
+		return TypeIVec3, len(value)
+	case [][4]int32:
+		return TypeIVec4, len(value)
+	case []uint32:
+		return TypeUint, len(value)
+	case []float32:
+		return TypeFloat, len(value)
+	case [][2]float32, []tensor.Vector2[float32]:
+		return TypeVec2, reflect.ValueOf(value).Len()
+	case [][3]float32, []tensor.Vector3[float32]:
+		return TypeVec3, reflect.ValueOf(value).Len()
+	case [][4]float32, []tensor.Vector4[float32]:
+		return TypeVec4, reflect.ValueOf(value).Len()
+	case []tensor.Matrix3[float32]:
+		return TypeMat3, len(value)
+	case []tensor.Matrix4[float32]:
+		return TypeMat4, len(value)
+	}
+	return uniformScalarTypeOf(uniform), 1
+}
+
+func uniformScalarTypeOf(uniform shader.Uniform) DataType {
+	switch uniform.(type) {
+	case bool:
+		return TypeBool
+	case int, int8, int16, int32:
+		return TypeInt
+	case [2]int, [2]int8, [2]int16, [2]int32:
+		return TypeIVec2
+	case [3]int, [3]int8, [3]int16, [3]int32:
+		return TypeIVec3
+	case [4]int, [4]int8, [4]int16, [4]int32:
+		return TypeIVec4
+	case uint, uint8, uint16, uint32:
+		return TypeUint
+	case [2]uint, [2]uint8, [2]uint16, [2]uint32:
+		return TypeUVec2
+	case [3]uint, [3]uint8, [3]uint16, [3]uint32:
+		return TypeUVec3
+	case [4]uint, [4]uint8, [4]uint16, [4]uint32:
+		return TypeUVec4
+	case float32:
+		return TypeFloat
+	case [2]float32, tensor.Vector2[float32]:
+		return TypeVec2
+	case [3]float32, tensor.Vector3[float32]:
+		return TypeVec3
+	case [4]float32, tensor.Vector4[float32]:
+		return TypeVec4
+	case float64:
+		return TypeDouble
+	case [2]float64, tensor.Vector2[float64]:
+		return TypeDVec2
+	case [3]float64, tensor.Vector3[float64]:
+		return TypeDVec3
+	case [4]float64, tensor.Vector4[float64]:
+		return TypeDVec4
+	case tensor.Matrix2[float32]:
+		return TypeMat2
+	case tensor.Matrix3[float32]:
+		return TypeMat3
+	case tensor.Matrix4[float32]:
+		return TypeMat4
+	case tensor.Matrix2[float64]:
+		return TypeDMat2
+	case tensor.Matrix3[float64]:
+		return TypeDMat3
+	case tensor.Matrix4[float64]:
+		return TypeDMat4
+	default:
+		return TypeUnknown
+	}
+}
+
+func uploadUniform(location int32, uniform shader.Uniform) bool {
+	if n := reflect.ValueOf(uniform); n.Kind() == reflect.Slice && n.Len() == 0 {
+		return true
+	}
+	switch value := uniform.(type) {
+	case []int32:
+		gl.Uniform1iv(location, int32(len(value)), &value[0])
+	case [][2]int32:
+		gl.Uniform2iv(location, int32(len(value)), &value[0][0])
+	case [][3]int32:
+		gl.Uniform3iv(location, int32(len(value)), &value[0][0])
+	case [][4]int32:
+		gl.Uniform4iv(location, int32(len(value)), &value[0][0])
+	case []uint32:
+		gl.Uniform1uiv(location, int32(len(value)), &value[0])
+	case []float32:
+		gl.Uniform1fv(location, int32(len(value)), &value[0])
+	case [][2]float32:
+		gl.Uniform2fv(location, int32(len(value)), &value[0][0])
+	case []tensor.Vector2[float32]:
+		gl.Uniform2fv(location, int32(len(value)), &value[0][0])
+	case [][3]float32:
+		gl.Uniform3fv(location, int32(len(value)), &value[0][0])
+	case []tensor.Vector3[float32]:
+		gl.Uniform3fv(location, int32(len(value)), &value[0][0])
+	case [][4]float32:
+		gl.Uniform4fv(location, int32(len(value)), &value[0][0])
+	case []tensor.Vector4[float32]:
+		gl.Uniform4fv(location, int32(len(value)), &value[0][0])
+	case []tensor.Matrix3[float32]:
+		gl.UniformMatrix3fv(location, int32(len(value)), false, &value[0][0])
+	case []tensor.Matrix4[float32]:
+		gl.UniformMatrix4fv(location, int32(len(value)), false, &value[0][0])
+	case bool:
+		gl.Uniform1i(location, operator.Bool[int32](value))
+	case int:
+		gl.Uniform1i(location, int32(value))
+	case [2]int:
+		gl.Uniform2i(location, int32(value[0]), int32(value[1]))
+	case [3]int:
+		gl.Uniform3i(location, int32(value[0]), int32(value[1]), int32(value[2]))
+	case [4]int:
+		gl.Uniform4i(location, int32(value[0]), int32(value[1]), int32(value[2]), int32(value[3]))
+	case int8:
+		gl.Uniform1i(location, int32(value))
+	case [2]int8:
+		gl.Uniform2i(location, int32(value[0]), int32(value[1]))
+	case [3]int8:
+		gl.Uniform3i(location, int32(value[0]), int32(value[1]), int32(value[2]))
+	case [4]int8:
+		gl.Uniform4i(location, int32(value[0]), int32(value[1]), int32(value[2]), int32(value[3]))
+	case int16:
+		gl.Uniform1i(location, int32(value))
+	case [2]int16:
+		gl.Uniform2i(location, int32(value[0]), int32(value[1]))
+	case [3]int16:
+		gl.Uniform3i(location, int32(value[0]), int32(value[1]), int32(value[2]))
+	case [4]int16:
+		gl.Uniform4i(location, int32(value[0]), int32(value[1]), int32(value[2]), int32(value[3]))
+	case int32:
+		gl.Uniform1i(location, int32(value))
+	case [2]int32:
+		gl.Uniform2i(location, int32(value[0]), int32(value[1]))
+	case [3]int32:
+		gl.Uniform3i(location, int32(value[0]), int32(value[1]), int32(value[2]))
+	case [4]int32:
+		gl.Uniform4i(location, int32(value[0]), int32(value[1]), int32(value[2]), int32(value[3]))
+	case uint:
+		gl.Uniform1ui(location, uint32(value))
+	case [2]uint:
+		gl.Uniform2ui(location, uint32(value[0]), uint32(value[1]))
+	case [3]uint:
+		gl.Uniform3ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]))
+	case [4]uint:
+		gl.Uniform4ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]), uint32(value[3]))
+	case uint8:
+		gl.Uniform1ui(location, uint32(value))
+	case [2]uint8:
+		gl.Uniform2ui(location, uint32(value[0]), uint32(value[1]))
+	case [3]uint8:
+		gl.Uniform3ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]))
+	case [4]uint8:
+		gl.Uniform4ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]), uint32(value[3]))
+	case uint16:
+		gl.Uniform1ui(location, uint32(value))
+	case [2]uint16:
+		gl.Uniform2ui(location, uint32(value[0]), uint32(value[1]))
+	case [3]uint16:
+		gl.Uniform3ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]))
+	case [4]uint16:
+		gl.Uniform4ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]), uint32(value[3]))
+	case uint32:
+		gl.Uniform1ui(location, uint32(value))
+	case [2]uint32:
+		gl.Uniform2ui(location, uint32(value[0]), uint32(value[1]))
+	case [3]uint32:
+		gl.Uniform3ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]))
+	case [4]uint32:
+		gl.Uniform4ui(location, uint32(value[0]), uint32(value[1]), uint32(value[2]), uint32(value[3]))
+	case float32:
+		gl.Uniform1f(location, value)
+	case [2]float32:
+		gl.Uniform2f(location, value[0], value[1])
+	case tensor.Vector2[float32]:
+		gl.Uniform2f(location, value.X(), value.Y())
+	case [3]float32:
+		gl.Uniform3f(location, value[0], value[1], value[2])
+	case tensor.Vector3[float32]:
+		gl.Uniform3f(location, value.X(), value.Y(), value.Z())
+	case [4]float32:
+		gl.Uniform4f(location, value[0], value[1], value[2], value[3])
+	case tensor.Vector4[float32]:
+		gl.Uniform4f(location, value.X(), value.Y(), value.Z(), value.W())
+	case float64:
+		gl.Uniform1d(location, value)
+	case [2]float64:
+		gl.Uniform2d(location, value[0], value[1])
+	case tensor.Vector2[float64]:
+		gl.Uniform2d(location, value.X(), value.Y())
+	case [3]float64:
+		gl.Uniform3d(location, value[0], value[1], value[2])
+	case tensor.Vector3[float64]:
+		gl.Uniform3d(location, value.X(), value.Y(), value.Z())
+	case [4]float64:
+		gl.Uniform4d(location, value[0], value[1], value[2], value[3])
+	case tensor.Vector4[float64]:
+		gl.Uniform4d(location, value.X(), value.Y(), value.Z(), value.W())
+	case tensor.Matrix2[float32]:
+		gl.UniformMatrix2fv(location, 1, false, &value[0])
+	case tensor.Matrix2[float64]:
+		gl.UniformMatrix2dv(location, 1, false, &value[0])
+	case tensor.Matrix3[float32]:
+		gl.UniformMatrix3fv(location, 1, false, &value[0])
+	case tensor.Matrix3[float64]:
+		gl.UniformMatrix3dv(location, 1, false, &value[0])
+	case tensor.Matrix4[float32]:
+		gl.UniformMatrix4fv(location, 1, false, &value[0])
+	case tensor.Matrix4[float64]:
+		gl.UniformMatrix4dv(location, 1, false, &value[0])
+	default:
+		return false
+	}
+	return true
+}
+
+func glDataType(xtype uint32) DataType {
+	switch xtype {
+	case gl.FLOAT:
+		return TypeFloat
+	case gl.FLOAT_VEC2:
+		return TypeVec2
+	case gl.FLOAT_VEC3:
+		return TypeVec3
+	case gl.FLOAT_VEC4:
+		return TypeVec4
+	case gl.DOUBLE:
+		return TypeDouble
+	case gl.DOUBLE_VEC2:
+		return TypeDVec2
+	case gl.DOUBLE_VEC3:
+		return TypeDVec3
+	case gl.DOUBLE_VEC4:
+		return TypeDVec4
+	case gl.INT:
+		return TypeInt
+	case gl.INT_VEC2:
+		return TypeIVec2
+	case gl.INT_VEC3:
+		return TypeIVec3
+	case gl.INT_VEC4:
+		return TypeIVec4
+	case gl.UNSIGNED_INT:
+		return TypeUint
+	case gl.UNSIGNED_INT_VEC2:
+		return TypeUVec2
+	case gl.UNSIGNED_INT_VEC3:
+		return TypeUVec3
+	case gl.UNSIGNED_INT_VEC4:
+		return TypeUVec4
+	case gl.BOOL:
+		return TypeBool
+	case gl.BOOL_VEC2:
+		return TypeBVec2
+	case gl.BOOL_VEC3:
+		return TypeBVec3
+	case gl.BOOL_VEC4:
+		return TypeBVec4
+	case gl.FLOAT_MAT2:
+		return TypeMat2
+	case gl.FLOAT_MAT3:
+		return TypeMat3
+	case gl.FLOAT_MAT4:
+		return TypeMat4
+	case gl.DOUBLE_MAT2:
+		return TypeDMat2
+	case gl.DOUBLE_MAT3:
+		return TypeDMat3
+	case gl.DOUBLE_MAT4:
+		return TypeDMat4
+	case gl.SAMPLER_1D, gl.SAMPLER_2D, gl.SAMPLER_3D, gl.SAMPLER_CUBE,
+		gl.SAMPLER_1D_SHADOW, gl.SAMPLER_2D_SHADOW, gl.SAMPLER_CUBE_SHADOW,
+		gl.SAMPLER_1D_ARRAY, gl.SAMPLER_2D_ARRAY, gl.SAMPLER_2D_ARRAY_SHADOW,
+		gl.SAMPLER_2D_MULTISAMPLE, gl.SAMPLER_BUFFER, gl.SAMPLER_2D_RECT,
+		gl.INT_SAMPLER_2D, gl.INT_SAMPLER_3D, gl.INT_SAMPLER_CUBE, gl.INT_SAMPLER_2D_ARRAY,
+		gl.UNSIGNED_INT_SAMPLER_2D, gl.UNSIGNED_INT_SAMPLER_3D, gl.UNSIGNED_INT_SAMPLER_CUBE,
+		gl.UNSIGNED_INT_SAMPLER_2D_ARRAY:
+		return TypeSampler
+	default:
+		return TypeUnknown
+	}
+}
@@ -0,0 +1,166 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+type glBuffer struct {
+	id    uint32
+	bytes int
+}
+
+type vertexArray struct {
+	buffers    map[uint32]glBuffer // buffers by attribute location
+	indices    glBuffer
+	indexCount int
+}
+
+// CreateVertexArray implements Renderer CreateVertexArray method
+func (renderer *openglRenderer) CreateVertexArray() uint32 {
+	var id uint32
+	gl.GenVertexArrays(1, &id)
+	if renderer.vertexArrays == nil {
+		renderer.vertexArrays = make(map[uint32]*vertexArray)
+	}
+	renderer.vertexArrays[id] = &vertexArray{
+		buffers: make(map[uint32]glBuffer),
+	}
+	return id
+}
+
+// DeleteVertexArray implements Renderer DeleteVertexArray method
+func (renderer *openglRenderer) DeleteVertexArray(vao uint32) {
+	va, ok := renderer.vertexArrays[vao]
+	if !ok {
+		return
+	}
+	for _, buffer := range va.buffers {
+		gl.DeleteBuffers(1, &buffer.id)
+	}
+	if va.indices.id != 0 {
+		gl.DeleteBuffers(1, &va.indices.id)
+	}
+	gl.DeleteVertexArrays(1, &vao)
+	delete(renderer.vertexArrays, vao)
+}
+
+func glVertexType(data any) (xtype uint32, integer bool, length, size int, ok bool) {
+	switch data := data.(type) {
+	case []int8:
+		return gl.BYTE, true, len(data), 1, true
+	case []int16:
+		return gl.SHORT, true, len(data), 2, true
+	case []int32:
+		return gl.INT, true, len(data), 4, true
+	case []uint8:
+		return gl.UNSIGNED_BYTE, true, len(data), 1, true
+	case []uint16:
+		return gl.UNSIGNED_SHORT, true, len(data), 2, true
+	case []uint32:
+		return gl.UNSIGNED_INT, true, len(data), 4, true
+	case []float32:
+		return gl.FLOAT, false, len(data), 4, true
+	case []float64:
+		return gl.DOUBLE, false, len(data), 8, true
+	}
+	return
+}
+
+// uploadBuffer uploads data to buffer, the buffer is created if not exist
+// and reallocated if size changed
+func uploadBuffer(target uint32, buffer *glBuffer, data any, bytes int) {
+	if buffer.id == 0 {
+		gl.GenBuffers(1, &buffer.id)
+	}
+	gl.BindBuffer(target, buffer.id)
+	if bytes == 0 {
+		gl.BufferData(target, 0, nil, gl.STATIC_DRAW)
+	} else if buffer.bytes != bytes {
+		gl.BufferData(target, bytes, gl.Ptr(data), gl.STATIC_DRAW)
+	} else {
+		gl.BufferSubData(target, 0, bytes, gl.Ptr(data))
+	}
+	buffer.bytes = bytes
+}
+
+// SetVertexAttribute implements Renderer SetVertexAttribute method
+func (renderer *openglRenderer) SetVertexAttribute(vao uint32, attribute VertexAttribute) error {
+	va, ok := renderer.vertexArrays[vao]
+	if !ok {
+		return fmt.Errorf("renderer: vertex array %d not found", vao)
+	}
+	xtype, integer, length, size, ok := glVertexType(attribute.Data)
+	if !ok {
+		return fmt.Errorf("renderer: unsupported vertex attribute data %T", attribute.Data)
+	}
+	if attribute.Size < 1 || attribute.Size > 4 {
+		return fmt.Errorf("renderer: invalid vertex attribute size %d", attribute.Size)
+	}
+	gl.BindVertexArray(vao)
+	var buffer = va.buffers[attribute.Location]
+	uploadBuffer(gl.ARRAY_BUFFER, &buffer, attribute.Data, length*size)
+	va.buffers[attribute.Location] = buffer
+	if integer {
+		gl.VertexAttribIPointer(attribute.Location, int32(attribute.Size), xtype, 0, nil)
+	} else {
+		gl.VertexAttribPointer(attribute.Location, int32(attribute.Size), xtype, false, 0, nil)
+	}
+	gl.EnableVertexAttribArray(attribute.Location)
+	gl.BindVertexArray(0)
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+	return nil
+}
+
+// SetIndices implements Renderer SetIndices method
+func (renderer *openglRenderer) SetIndices(vao uint32, indices []uint32) {
+	va, ok := renderer.vertexArrays[vao]
+	if !ok {
+		return
+	}
+	gl.BindVertexArray(vao)
+	uploadBuffer(gl.ELEMENT_ARRAY_BUFFER, &va.indices, indices, len(indices)*4)
+	va.indexCount = len(indices)
+	gl.BindVertexArray(0)
+}
+
+func glDrawMode(mode DrawMode) uint32 {
+	switch mode {
+	case TriangleStrip:
+		return gl.TRIANGLE_STRIP
+	case TriangleFan:
+		return gl.TRIANGLE_FAN
+	case Lines:
+		return gl.LINES
+	case LineStrip:
+		return gl.LINE_STRIP
+	case LineLoop:
+		return gl.LINE_LOOP
+	case Points:
+		return gl.POINTS
+	default:
+		return gl.TRIANGLES
+	}
+}
+
+// Draw implements Renderer Draw method
+func (renderer *openglRenderer) Draw(vao uint32, mode DrawMode, first, count int) {
+	va, ok := renderer.vertexArrays[vao]
+	if !ok || count <= 0 {
+		return
+	}
+	gl.BindVertexArray(vao)
+	if va.indices.id != 0 {
+		gl.DrawElements(glDrawMode(mode), int32(count), gl.UNSIGNED_INT, gl.PtrOffset(first*4))
+	} else {
+		gl.DrawArrays(glDrawMode(mode), int32(first), int32(count))
+	}
+	gl.BindVertexArray(0)
+
+	var triangles, lines, points = mode.primitives(count)
+	renderer.stats.DrawCalls++
+	renderer.stats.Triangles += triangles
+	renderer.stats.Lines += lines
+	renderer.stats.Points += points
+}
@@ -0,0 +1,114 @@
+package renderer
+
+import "strconv"
+
+// DataType represents GLSL type of an uniform or attribute
+type DataType int
+
+const (
+	TypeUnknown DataType = iota
+	TypeFloat
+	TypeVec2
+	TypeVec3
+	TypeVec4
+	TypeDouble
+	TypeDVec2
+	TypeDVec3
+	TypeDVec4
+	TypeInt
+	TypeIVec2
+	TypeIVec3
+	TypeIVec4
+	TypeUint
+	TypeUVec2
+	TypeUVec3
+	TypeUVec4
+	TypeBool
+	TypeBVec2
+	TypeBVec3
+	TypeBVec4
+	TypeMat2
+	TypeMat3
+	TypeMat4
+	TypeDMat2
+	TypeDMat3
+	TypeDMat4
+	TypeSampler // TypeSampler represents all kinds of samplers
+)
+
+var dataTypeNames = [...]string{
+	TypeUnknown: "unknown",
+	TypeFloat:   "float",
+	TypeVec2:    "vec2",
+	TypeVec3:    "vec3",
+	TypeVec4:    "vec4",
+	TypeDouble:  "double",
+	TypeDVec2:   "dvec2",
+	TypeDVec3:   "dvec3",
+	TypeDVec4:   "dvec4",
+	TypeInt:     "int",
+	TypeIVec2:   "ivec2",
+	TypeIVec3:   "ivec3",
+	TypeIVec4:   "ivec4",
+	TypeUint:    "uint",
+	TypeUVec2:   "uvec2",
+	TypeUVec3:   "uvec3",
+	TypeUVec4:   "uvec4",
+	TypeBool:    "bool",
+	TypeBVec2:   "bvec2",
+	TypeBVec3:   "bvec3",
+	TypeBVec4:   "bvec4",
+	TypeMat2:    "mat2",
+	TypeMat3:    "mat3",
+	TypeMat4:    "mat4",
+	TypeDMat2:   "dmat2",
+	TypeDMat3:   "dmat3",
+	TypeDMat4:   "dmat4",
+	TypeSampler: "sampler",
+}
+
+// String implements fmt.Stringer String method
+func (t DataType) String() string {
+	if t >= 0 && int(t) < len(dataTypeNames) {
+		return dataTypeNames[t]
+	}
+	return "DataType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// Accepts reports whether a value of Go type mapped to t can be uploaded
+// to an uniform of the type, booleans accept any scalar of the same
+// dimension and samplers accept integer texture units
+func (t DataType) Accepts(value DataType) bool {
+	if t == value {
+		return true
+	}
+	switch t {
+	case TypeBool:
+		return value == TypeInt || value == TypeUint || value == TypeFloat
+	case TypeBVec2:
+		return value == TypeIVec2 || value == TypeUVec2 || value == TypeVec2
+	case TypeBVec3:
+		return value == TypeIVec3 || value == TypeUVec3 || value == TypeVec3
+	case TypeBVec4:
+		return value == TypeIVec4 || value == TypeUVec4 || value == TypeVec4
+	case TypeSampler:
+		return value == TypeInt
+	}
+	return false
+}
+
+// UniformInfo describes an active uniform of program
+type UniformInfo struct {
+	Name     string // Name of uniform, suffix `[0]' of arrays is trimmed
+	Type     DataType
+	Size     int // Size is number of elements of array, 1 for non-array
+	Location int32
+}
+
+// AttributeInfo describes an active vertex attribute of program
+type AttributeInfo struct {
+	Name     string
+	Type     DataType
+	Size     int
+	Location int32
+}
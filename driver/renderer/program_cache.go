@@ -0,0 +1,114 @@
+package renderer
+
+import (
+	"errors"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer/shader"
+)
+
+type programKey struct {
+	vertex   string
+	fragment string
+	variant  string
+}
+
+type programEntry struct {
+	key     programKey
+	program Program
+	refs    int
+}
+
+// programCache shares programs created from the same shader sources and
+// variant, a program is cleared when it's no longer referenced. Sources
+// failed to compile are not compiled again until sources, defines or
+// revision of chunks changed
+type programCache struct {
+	programs map[programKey]*programEntry
+	byId     map[uint32]*programEntry
+	failures map[programKey]error
+	revision int // revision of chunks failures recorded with
+}
+
+func (cache *programCache) acquire(renderer Renderer, backend shader.Backend, source shader.Shader) (Program, error) {
+	var variant = shader.Variant{Backend: backend, Defines: source.Defines}
+	var key = programKey{
+		vertex:   source.Vertex,
+		fragment: source.Fragment,
+		variant:  backend.String() + "|" + source.Key(),
+	}
+	if entry, ok := cache.programs[key]; ok {
+		entry.refs++
+		return entry.program, nil
+	}
+	if revision := shader.Revision(); cache.revision != revision {
+		// keys of failures include revision, drop the stale ones
+		cache.revision = revision
+		cache.failures = nil
+	}
+	if err, ok := cache.failures[key]; ok {
+		return Program{}, err
+	}
+	program, err := cache.create(renderer, variant, source)
+	if err != nil {
+		if cache.failures == nil {
+			cache.failures = make(map[programKey]error)
+		}
+		cache.failures[key] = err
+		return program, err
+	}
+	if cache.programs == nil {
+		cache.programs = make(map[programKey]*programEntry)
+		cache.byId = make(map[uint32]*programEntry)
+	}
+	var entry = &programEntry{
+		key:     key,
+		program: program,
+		refs:    1,
+	}
+	cache.programs[key] = entry
+	cache.byId[program.Id] = entry
+	core.LogResource.Debug("program created", "id", program.Id, "vertex", source.Name(shader.VertexStage), "fragment", source.Name(shader.FragmentStage))
+	return program, nil
+}
+
+// create preprocesses sources and creates program, lines of compile errors
+// are mapped back to the source files
+func (cache *programCache) create(renderer Renderer, variant shader.Variant, source shader.Shader) (Program, error) {
+	vshader, vmap, err := shader.PreprocessWithSourceMap(source.Name(shader.VertexStage), source.Vertex, variant)
+	if err != nil {
+		return Program{}, err
+	}
+	fshader, fmap, err := shader.PreprocessWithSourceMap(source.Name(shader.FragmentStage), source.Fragment, variant)
+	if err != nil {
+		return Program{}, err
+	}
+	program, err := renderer.CreateProgram(vshader, fshader)
+	if err != nil {
+		var compileErr *shader.CompileError
+		if errors.As(err, &compileErr) {
+			switch compileErr.Stage {
+			case shader.VertexStage:
+				compileErr.MapSource(vmap)
+			case shader.FragmentStage:
+				compileErr.MapSource(fmap)
+			}
+		}
+	}
+	return program, err
+}
+
+func (cache *programCache) release(renderer Renderer, program Program) {
+	entry, ok := cache.byId[program.Id]
+	if !ok {
+		return
+	}
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+	delete(cache.programs, entry.key)
+	delete(cache.byId, program.Id)
+	renderer.ClearProgram(entry.program)
+	core.LogResource.Debug("program cleared", "id", program.Id)
+}
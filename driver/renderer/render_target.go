@@ -0,0 +1,20 @@
+package renderer
+
+// RenderTarget represents an offscreen framebuffer whose color buffer is a
+// texture, the zero RenderTarget represents the default framebuffer
+type RenderTarget struct {
+	Id      uint32 // Id of framebuffer
+	Texture uint32 // Texture attached as color buffer
+	Depth   uint32 // Depth is the depth-stencil renderbuffer
+	Width   int
+	Height  int
+}
+
+// IsDefault reports whether the target is the default framebuffer
+func (target RenderTarget) IsDefault() bool {
+	return target.Id == 0
+}
+
+// renderTargetPixelBytes is bytes per pixel of a render target, RGBA8 color
+// texture and DEPTH24_STENCIL8 renderbuffer
+const renderTargetPixelBytes = 4 + 4
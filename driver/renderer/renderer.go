@@ -1,17 +1,92 @@
 package renderer
 
-import "github.com/gopherd/three/driver/renderer/shader"
+import (
+	"image"
+
+	"github.com/gopherd/three/driver/renderer/shader"
+)
 
 type Renderer interface {
 	Init(width, height int) error
 	Viewport(x, y, w, h int32)
+	// GetViewport returns viewport of the default framebuffer
+	GetViewport() (x, y, w, h int32)
+	// ClearColor clears all buffers of the bound target, color buffer is
+	// cleared to the color
 	ClearColor(r, g, b, a float32)
+	// Clear clears buffers in the mask of the bound target, color buffer is
+	// cleared to the color, clearing is restricted by the scissor
+	Clear(mask ClearMask, r, g, b, a float32)
+	// Scissor restricts clearing and drawing to the rectangle in pixels of
+	// the bound target, an empty rectangle disables it
+	Scissor(x, y, w, h int32)
+	SetState(state State)
 	CreateProgram(vshader, fshader string) (Program, error)
 	ClearProgram(Program)
+	// AcquireProgram returns a program shared by the same shader sources
+	// and defines, sources are preprocessed before compiling
+	AcquireProgram(source shader.Shader) (Program, error)
+	// ReleaseProgram releases the program acquired by AcquireProgram, the
+	// program is cleared if it's no longer referenced
+	ReleaseProgram(Program)
 	LinkProgram(program uint32) error
-	SetUniform(program uint32, name string, uniform shader.Uniform)
+	UseProgram(program uint32)
+	// Uniforms returns active uniforms of the program
+	Uniforms(program uint32) []UniformInfo
+	// Attributes returns active vertex attributes of the program
+	Attributes(program uint32) []AttributeInfo
+	// SetUniform sets value of the uniform, the value is uploaded only if
+	// changed, inactive uniforms are ignored
+	SetUniform(program uint32, name string, uniform shader.Uniform) error
+	// SetUniformBlock uploads value with std140 layout to the uniform buffer
+	// shared by all programs which declare the block name, see EncodeStd140
+	SetUniformBlock(name string, value any) error
+
+	CreateVertexArray() uint32
+	DeleteVertexArray(vao uint32)
+	// SetVertexAttribute uploads data of vertex attribute to the vertex array
+	SetVertexAttribute(vao uint32, attribute VertexAttribute) error
+	// SetIndices uploads indices to the vertex array, vertex arrays with
+	// indices are drawn by indices
+	SetIndices(vao uint32, indices []uint32)
+	// Draw draws count vertices or indices from first of the vertex array
+	Draw(vao uint32, mode DrawMode, first, count int)
+
+	// CreateRenderTarget creates an offscreen render target with a color
+	// texture and a depth-stencil buffer
+	CreateRenderTarget(width, height int) (RenderTarget, error)
+	DeleteRenderTarget(target RenderTarget)
+	// BindRenderTarget binds the target for following rendering, the zero
+	// RenderTarget binds the default framebuffer
+	BindRenderTarget(target RenderTarget)
+	// DrawTexture draws the texture over the whole bound target blended by
+	// opacity, alpha of the texture is ignored. The offset is in units of
+	// target size
+	DrawTexture(texture uint32, opacity float32, offsetX, offsetY float32) error
+	// ReadPixels reads color buffer of the target as an image with top-down
+	// rows, the viewport is read if target is the default framebuffer
+	ReadPixels(target RenderTarget) (*image.RGBA, error)
+
+	// BeginFrame resets per-frame counters of stats
+	BeginFrame()
+	EndFrame()
+	// Stats returns stats of current frame
+	Stats() Stats
+	// SetGPUTimer enables or disables GPU timer queries
+	SetGPUTimer(enabled bool)
 }
 
+// ClearMask specifies buffers to clear
+type ClearMask int
+
+const (
+	ClearColorBuffer ClearMask = 1 << iota
+	ClearDepthBuffer
+	ClearStencilBuffer
+
+	ClearAll = ClearColorBuffer | ClearDepthBuffer | ClearStencilBuffer
+)
+
 type Program struct {
 	Id               uint32
 	VertextShaderId  uint32
@@ -0,0 +1,5 @@
+layout(std140) uniform Camera {
+	mat4 cameraProjection;
+	mat4 cameraView;
+	vec3 cameraPosition;
+};
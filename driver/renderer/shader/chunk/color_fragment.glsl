@@ -0,0 +1,3 @@
+#ifdef USE_VERTEX_COLORS
+diffuseColor.rgb *= vColor;
+#endif
@@ -0,0 +1,3 @@
+#ifdef USE_VERTEX_COLORS
+in vec3 vColor;
+#endif
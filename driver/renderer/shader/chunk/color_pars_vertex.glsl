@@ -0,0 +1,4 @@
+#ifdef USE_VERTEX_COLORS
+in vec3 color;
+out vec3 vColor;
+#endif
@@ -0,0 +1,3 @@
+#ifdef USE_VERTEX_COLORS
+vColor = color;
+#endif
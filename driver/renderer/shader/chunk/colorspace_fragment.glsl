@@ -0,0 +1,3 @@
+#ifdef USE_SRGB_OUTPUT
+fragColor = LinearToSRGB(fragColor);
+#endif
@@ -0,0 +1,8 @@
+#ifdef USE_FOG
+#ifdef FOG_EXP2
+float fogFactor = 1.0 - exp(-fogDensity * fogDensity * vFogDepth * vFogDepth);
+#else
+float fogFactor = smoothstep(fogNear, fogFar, vFogDepth);
+#endif
+fragColor.rgb = mix(fragColor.rgb, fogColor, fogFactor);
+#endif
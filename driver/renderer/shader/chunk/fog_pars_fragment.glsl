@@ -0,0 +1,10 @@
+#ifdef USE_FOG
+uniform vec3 fogColor;
+in float vFogDepth;
+#ifdef FOG_EXP2
+uniform float fogDensity;
+#else
+uniform float fogNear;
+uniform float fogFar;
+#endif
+#endif
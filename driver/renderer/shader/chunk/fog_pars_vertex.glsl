@@ -0,0 +1,3 @@
+#ifdef USE_FOG
+out float vFogDepth;
+#endif
@@ -0,0 +1,3 @@
+#ifdef USE_FOG
+vFogDepth = -mvPosition.z;
+#endif
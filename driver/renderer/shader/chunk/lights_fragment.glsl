@@ -0,0 +1,11 @@
+vec3 irradiance = ambientLightColor;
+for (int i = 0; i < numDirLights; i++) {
+	irradiance += directionalLights[i].color * saturate(dot(normal, directionalLights[i].direction));
+}
+for (int i = 0; i < numPointLights; i++) {
+	vec3 lVector = pointLights[i].position - vViewPosition;
+	float lDistance = length(lVector);
+	float attenuation = pointLights[i].distance > 0.0 ? pow(saturate(1.0 - lDistance / pointLights[i].distance), pointLights[i].decay) : 1.0;
+	irradiance += pointLights[i].color * attenuation * saturate(dot(normal, normalize(lVector)));
+}
+diffuseColor.rgb *= irradiance;
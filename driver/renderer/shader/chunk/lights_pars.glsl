@@ -0,0 +1,19 @@
+#define MAX_DIR_LIGHTS 4
+#define MAX_POINT_LIGHTS 4
+struct DirectionalLight {
+	vec3 direction;
+	vec3 color;
+};
+struct PointLight {
+	vec3 position;
+	vec3 color;
+	float distance;
+	float decay;
+};
+layout(std140) uniform Lights {
+	vec3 ambientLightColor;
+	int numDirLights;
+	int numPointLights;
+	DirectionalLight directionalLights[MAX_DIR_LIGHTS];
+	PointLight pointLights[MAX_POINT_LIGHTS];
+};
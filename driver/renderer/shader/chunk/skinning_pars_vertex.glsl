@@ -0,0 +1,10 @@
+#ifdef USE_SKINNING
+#ifndef MAX_BONES
+#define MAX_BONES 64
+#endif
+in vec4 skinIndex;
+in vec4 skinWeight;
+uniform mat4 bindMatrix;
+uniform mat4 bindMatrixInverse;
+uniform mat4 boneMatrices[MAX_BONES];
+#endif
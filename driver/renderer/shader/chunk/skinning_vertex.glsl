@@ -0,0 +1,9 @@
+#ifdef USE_SKINNING
+vec4 skinVertex = bindMatrix * vec4(transformed, 1.0);
+vec4 skinned = vec4(0.0);
+skinned += boneMatrices[int(skinIndex.x)] * skinVertex * skinWeight.x;
+skinned += boneMatrices[int(skinIndex.y)] * skinVertex * skinWeight.y;
+skinned += boneMatrices[int(skinIndex.z)] * skinVertex * skinWeight.z;
+skinned += boneMatrices[int(skinIndex.w)] * skinVertex * skinWeight.w;
+transformed = (bindMatrixInverse * skinned).xyz;
+#endif
@@ -0,0 +1,153 @@
+package shader
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Stage represents a programmable stage of pipeline
+type Stage int
+
+const (
+	VertexStage Stage = iota
+	FragmentStage
+	LinkStage // LinkStage represents linking stages into a program
+)
+
+// String implements fmt.Stringer String method
+func (stage Stage) String() string {
+	switch stage {
+	case VertexStage:
+		return "vertex"
+	case FragmentStage:
+		return "fragment"
+	case LinkStage:
+		return "link"
+	default:
+		return "stage(" + strconv.Itoa(int(stage)) + ")"
+	}
+}
+
+// Severity represents severity of a diagnostic
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+// String implements fmt.Stringer String method
+func (severity Severity) String() string {
+	if severity == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Diagnostic represents a message parsed from driver info log
+type Diagnostic struct {
+	Severity Severity
+	Line     int      // 1-based line of compiled source, 0 if unknown
+	Column   int      // 1-based column, 0 if unknown
+	Location Location // original location, zero if source map unavailable
+	Message  string
+	Excerpt  string // the offending line of source
+}
+
+// String implements fmt.Stringer String method
+func (d Diagnostic) String() string {
+	var buf strings.Builder
+	if d.Location.File != "" {
+		buf.WriteString(d.Location.String())
+	} else if d.Line > 0 {
+		buf.WriteString(strconv.Itoa(d.Line))
+	}
+	if buf.Len() > 0 {
+		if d.Column > 0 {
+			buf.WriteByte(':')
+			buf.WriteString(strconv.Itoa(d.Column))
+		}
+		buf.WriteString(": ")
+	}
+	buf.WriteString(d.Severity.String())
+	buf.WriteString(": ")
+	buf.WriteString(d.Message)
+	if d.Excerpt != "" {
+		buf.WriteString("\n\t")
+		buf.WriteString(d.Excerpt)
+	}
+	return buf.String()
+}
+
+// CompileError reports a failure of compiling shader stage or linking program
+type CompileError struct {
+	Stage       Stage
+	Log         string // raw driver info log
+	Diagnostics []Diagnostic
+}
+
+// Error implements error Error method
+func (err *CompileError) Error() string {
+	var buf strings.Builder
+	buf.WriteString(err.Stage.String())
+	buf.WriteString(" shader: ")
+	if len(err.Diagnostics) == 0 {
+		buf.WriteString(strings.TrimSpace(err.Log))
+		return buf.String()
+	}
+	for i := range err.Diagnostics {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(err.Diagnostics[i].String())
+	}
+	return buf.String()
+}
+
+// logLinePattern matches a line of driver info logs, e.g.
+//
+//	0(12) : error C0000: ...     (NVIDIA)
+//	0:12(5): error: ...          (Mesa)
+//	ERROR: 0:12: ...             (AMD, Apple, ANGLE)
+var logLinePattern = regexp.MustCompile(
+	`^(?:(ERROR|WARNING):\s*)?\d+(?::(\d+)(?:\((\d+)\))?|\((\d+)\))\s*:\s*(?:(error|warning)(?:\s+\w+)?:\s*)?(.*)$`,
+)
+
+// ParseLog parses driver info log of compiling source into a CompileError
+func ParseLog(stage Stage, log, source string) *CompileError {
+	var err = &CompileError{Stage: stage, Log: log}
+	var lines = splitLines(source)
+	for _, text := range strings.Split(log, "\n") {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		var match = logLinePattern.FindStringSubmatch(text)
+		if match == nil {
+			err.Diagnostics = append(err.Diagnostics, Diagnostic{Message: text})
+			continue
+		}
+		var d Diagnostic
+		d.Line, _ = strconv.Atoi(match[2] + match[4])
+		d.Column, _ = strconv.Atoi(match[3])
+		if strings.EqualFold(match[1], "warning") || match[5] == "warning" {
+			d.Severity = SeverityWarning
+		}
+		d.Message = match[6]
+		if d.Line > 0 && d.Line <= len(lines) {
+			d.Excerpt = strings.TrimSpace(lines[d.Line-1])
+		}
+		err.Diagnostics = append(err.Diagnostics, d)
+	}
+	return err
+}
+
+// MapSource sets original locations of diagnostics by the source map
+func (err *CompileError) MapSource(sourceMap SourceMap) {
+	for i := range err.Diagnostics {
+		if location, ok := sourceMap.Lookup(err.Diagnostics[i].Line); ok {
+			err.Diagnostics[i].Location = location
+		}
+	}
+}
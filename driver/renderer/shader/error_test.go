@@ -0,0 +1,74 @@
+package shader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLog(t *testing.T) {
+	const source = "line one\n  line two  \nline three\n"
+	for _, tt := range []struct {
+		name string
+		log  string
+		want []Diagnostic
+	}{
+		{
+			name: "nvidia",
+			log:  "0(2) : error C0000: syntax error\n",
+			want: []Diagnostic{{Line: 2, Message: "syntax error", Excerpt: "line two"}},
+		},
+		{
+			name: "mesa",
+			log:  "0:3(5): error: `x' undeclared\n0:1(1): warning: unused\n",
+			want: []Diagnostic{
+				{Line: 3, Column: 5, Message: "`x' undeclared", Excerpt: "line three"},
+				{Severity: SeverityWarning, Line: 1, Column: 1, Message: "unused", Excerpt: "line one"},
+			},
+		},
+		{
+			name: "amd",
+			log:  "ERROR: 0:1: 'foo' : undeclared identifier\nWARNING: 0:2: deprecated\n",
+			want: []Diagnostic{
+				{Line: 1, Message: "'foo' : undeclared identifier", Excerpt: "line one"},
+				{Severity: SeverityWarning, Line: 2, Message: "deprecated", Excerpt: "line two"},
+			},
+		},
+		{
+			name: "line out of source",
+			log:  "ERROR: 0:9: oops",
+			want: []Diagnostic{{Line: 9, Message: "oops"}},
+		},
+		{
+			name: "unrecognized",
+			log:  "\nLink failed.\n",
+			want: []Diagnostic{{Message: "Link failed."}},
+		},
+		{
+			name: "empty",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var err = ParseLog(FragmentStage, tt.log, source)
+			if err.Stage != FragmentStage || err.Log != tt.log {
+				t.Fatalf("got stage %v and log %q", err.Stage, err.Log)
+			}
+			if !reflect.DeepEqual(err.Diagnostics, tt.want) {
+				t.Fatalf("got diagnostics %+v, want %+v", err.Diagnostics, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrorMapSource(t *testing.T) {
+	var err = ParseLog(VertexStage, "ERROR: 0:2: bad\nERROR: 0:5: worse\n", "a\nb\n")
+	err.MapSource(SourceMap{{File: "<header>", Line: 1}, {File: "main", Line: 1}})
+	if got, want := err.Diagnostics[0].Location, (Location{File: "main", Line: 1}); got != want {
+		t.Fatalf("got location %v, want %v", got, want)
+	}
+	if got := err.Diagnostics[1].Location; got != (Location{}) {
+		t.Fatalf("got location %v of unmapped line, want zero", got)
+	}
+	if got, want := err.Error(), "vertex shader: main:1: error: bad\n\tb\n5: error: worse"; got != want {
+		t.Fatalf("got error %q, want %q", got, want)
+	}
+}
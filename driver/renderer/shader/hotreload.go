@@ -0,0 +1,146 @@
+package shader
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gopherd/three/core"
+)
+
+type sourceFile struct {
+	path    string
+	chunk   string // name of chunk if the file is loaded as a chunk
+	source  string
+	modTime time.Time
+	size    int64
+}
+
+func (file *sourceFile) load() error {
+	info, err := os.Stat(file.path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return err
+	}
+	file.source = string(data)
+	file.modTime = info.ModTime()
+	file.size = info.Size()
+	return nil
+}
+
+// changed reports whether the file is modified since last loaded
+func (file *sourceFile) changed() bool {
+	info, err := os.Stat(file.path)
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Equal(file.modTime) || info.Size() != file.size
+}
+
+var hotReload struct {
+	enabled  bool
+	interval time.Duration
+	polledAt time.Time
+	files    map[string]*sourceFile
+	onError  func(error)
+}
+
+// EnableHotReload enables development mode, shader files loaded by ReadFile
+// and LoadChunkFile are polled every interval and reloaded on change
+func EnableHotReload(interval time.Duration) {
+	hotReload.enabled = true
+	hotReload.interval = interval
+}
+
+// HotReloadEnabled reports whether the development mode is enabled
+func HotReloadEnabled() bool {
+	return hotReload.enabled
+}
+
+// SetReloadErrorHandler sets the handler called when a reloaded shader
+// fails to compile, the error is reported by core.ReportError by default
+func SetReloadErrorHandler(handler func(error)) {
+	hotReload.onError = handler
+}
+
+// ReportReloadError reports the error of compiling reloaded shader
+func ReportReloadError(err error) {
+	if hotReload.onError != nil {
+		hotReload.onError(err)
+	} else {
+		core.ReportError(fmt.Errorf("shader: reload failed, previous program stays active: %w", err))
+	}
+}
+
+// Revision returns a number increased whenever any chunk is changed
+func Revision() int {
+	return revision
+}
+
+func loadFile(path, chunk string) (*sourceFile, error) {
+	if file, ok := hotReload.files[path]; ok {
+		return file, nil
+	}
+	var file = &sourceFile{path: path, chunk: chunk}
+	if err := file.load(); err != nil {
+		return nil, err
+	}
+	if hotReload.files == nil {
+		hotReload.files = make(map[string]*sourceFile)
+	}
+	hotReload.files[path] = file
+	return file, nil
+}
+
+// ReadFile reads the shader source file, the content is cached and kept
+// up to date by Poll if hot reload is enabled
+func ReadFile(path string) (string, error) {
+	file, err := loadFile(path, "")
+	if err != nil {
+		return "", err
+	}
+	return file.source, nil
+}
+
+// LoadChunkFile registers a named chunk loaded from file
+func LoadChunkFile(name, path string) error {
+	file, err := loadFile(path, name)
+	if err != nil {
+		return err
+	}
+	chunkFiles[name] = path
+	RegisterChunk(name, file.source)
+	return nil
+}
+
+// Poll checks loaded files for changes if hot reload is enabled and the
+// interval elapsed, and returns paths of reloaded files
+func Poll() []string {
+	if !hotReload.enabled {
+		return nil
+	}
+	var now = time.Now()
+	if now.Sub(hotReload.polledAt) < hotReload.interval {
+		return nil
+	}
+	hotReload.polledAt = now
+	var changed []string
+	for path, file := range hotReload.files {
+		if !file.changed() {
+			continue
+		}
+		if err := file.load(); err != nil {
+			ReportReloadError(err)
+			continue
+		}
+		if file.chunk != "" {
+			RegisterChunk(file.chunk, file.source)
+		}
+		core.LogShader.Info("shader reloaded", "path", path)
+		changed = append(changed, path)
+	}
+	return changed
+}
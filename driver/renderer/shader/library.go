@@ -0,0 +1,102 @@
+package shader
+
+import (
+	"embed"
+	"path"
+	"strings"
+)
+
+//go:embed chunk/*.glsl
+var chunkFS embed.FS
+
+// chunks holds sources of chunks by name, built-in chunks are loaded from
+// files in directory chunk
+var chunks = loadChunks()
+
+func loadChunks() map[string]string {
+	entries, err := chunkFS.ReadDir("chunk")
+	if err != nil {
+		panic(err)
+	}
+	var chunks = make(map[string]string, len(entries))
+	for _, entry := range entries {
+		source, err := chunkFS.ReadFile(path.Join("chunk", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		chunks[strings.TrimSuffix(entry.Name(), ".glsl")] = string(source)
+	}
+	return chunks
+}
+
+// chunkFiles holds paths of chunks loaded from files
+var chunkFiles = map[string]string{}
+
+// revision is increased whenever any chunk is changed
+var revision int
+
+// chunkRevisions holds the revision at which each chunk was last changed
+var chunkRevisions = map[string]int{}
+
+// includedRevisions caches the latest revision of chunks included by a
+// source directly or indirectly, it's cleared whenever any chunk is changed
+var includedRevisions = map[string]int{}
+
+// RegisterChunk registers a named chunk which can be included by
+// `#include <name>`, an existing chunk with the same name is replaced
+func RegisterChunk(name, source string) {
+	if old, ok := chunks[name]; ok && old == source {
+		return
+	}
+	chunks[name] = source
+	revision++
+	chunkRevisions[name] = revision
+	for source := range includedRevisions {
+		delete(includedRevisions, source)
+	}
+}
+
+// includedRevision returns the latest revision of chunks included by
+// source, it's 0 if none of them has been changed since loaded
+func includedRevision(source string) int {
+	if rev, ok := includedRevisions[source]; ok {
+		return rev
+	}
+	var rev = walkIncludes(source, map[string]bool{})
+	includedRevisions[source] = rev
+	return rev
+}
+
+func walkIncludes(source string, visited map[string]bool) int {
+	var rev int
+	for _, line := range strings.Split(source, "\n") {
+		var match = includePattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if match == nil || visited[match[1]] {
+			continue
+		}
+		var name = match[1]
+		visited[name] = true
+		if chunkRevisions[name] > rev {
+			rev = chunkRevisions[name]
+		}
+		if chunk, ok := chunks[name]; ok {
+			if r := walkIncludes(chunk, visited); r > rev {
+				rev = r
+			}
+		}
+	}
+	return rev
+}
+
+func chunkFile(name string) string {
+	if path, ok := chunkFiles[name]; ok {
+		return path
+	}
+	return "<chunk " + name + ">"
+}
+
+// GetChunk retrieves the chunk by name
+func GetChunk(name string) (string, bool) {
+	source, ok := chunks[name]
+	return source, ok
+}
@@ -0,0 +1,204 @@
+package shader
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Backend represents the shading language dialect of a renderer
+type Backend int
+
+const (
+	GLSL330   Backend = iota // OpenGL 3.3 core profile
+	GLSL300ES                // OpenGL ES 3.0 and WebGL2
+)
+
+// String implements fmt.Stringer String method
+func (backend Backend) String() string {
+	switch backend {
+	case GLSL300ES:
+		return "glsl300es"
+	default:
+		return "glsl330"
+	}
+}
+
+// Header returns version and precision directives of the backend
+func (backend Backend) Header() string {
+	switch backend {
+	case GLSL300ES:
+		return "#version 300 es\nprecision highp float;\nprecision highp int;\n"
+	default:
+		return "#version 330 core\n"
+	}
+}
+
+// Defines holds preprocessor macros injected into shader sources, an empty
+// value defines a macro without value
+type Defines map[string]string
+
+// With returns a copy of defines merged with other, values of other
+// override the existing values
+func (defines Defines) With(other Defines) Defines {
+	var merged = make(Defines, len(defines)+len(other))
+	for name, value := range defines {
+		merged[name] = value
+	}
+	for name, value := range other {
+		merged[name] = value
+	}
+	return merged
+}
+
+func (defines Defines) names() []string {
+	var names = make([]string, 0, len(defines))
+	for name := range defines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Key returns a stable string which identifies the defines
+func (defines Defines) Key() string {
+	var buf strings.Builder
+	for i, name := range defines.names() {
+		if i > 0 {
+			buf.WriteByte(';')
+		}
+		buf.WriteString(name)
+		if value := defines[name]; value != "" {
+			buf.WriteByte('=')
+			buf.WriteString(value)
+		}
+	}
+	return buf.String()
+}
+
+// Source returns #define directives of the defines
+func (defines Defines) Source() string {
+	var buf strings.Builder
+	for _, name := range defines.names() {
+		buf.WriteString("#define ")
+		buf.WriteString(name)
+		if value := defines[name]; value != "" {
+			buf.WriteByte(' ')
+			buf.WriteString(value)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// Variant represents a combination of backend and defines, the same shader
+// is compiled once per variant
+type Variant struct {
+	Backend Backend
+	Defines Defines
+}
+
+// Key returns a stable string which identifies the variant
+func (variant Variant) Key() string {
+	return variant.Backend.String() + "|" + variant.Defines.Key()
+}
+
+var includePattern = regexp.MustCompile(`^[ \t]*#[ \t]*include[ \t]+<([\w./-]+)>[ \t]*$`)
+
+// Location represents a line of an original source file or chunk
+type Location struct {
+	File string
+	Line int
+}
+
+// String implements fmt.Stringer String method
+func (location Location) String() string {
+	return location.File + ":" + strconv.Itoa(location.Line)
+}
+
+// SourceMap maps lines of preprocessed source to original locations, the
+// ith element is location of line i+1
+type SourceMap []Location
+
+// Lookup returns the original location of 1-based line of preprocessed source
+func (sourceMap SourceMap) Lookup(line int) (Location, bool) {
+	if line < 1 || line > len(sourceMap) {
+		return Location{}, false
+	}
+	return sourceMap[line-1], true
+}
+
+// Preprocess resolves #include directives of source, and prepends version,
+// precision and define directives of the variant
+func Preprocess(source string, variant Variant) (string, error) {
+	output, _, err := PreprocessWithSourceMap("<source>", source, variant)
+	return output, err
+}
+
+// PreprocessWithSourceMap likes Preprocess, but returns the source map which
+// maps lines of output to the file named name and included chunks
+func PreprocessWithSourceMap(name, source string, variant Variant) (string, SourceMap, error) {
+	var p preprocessor
+	p.write("<header>", variant.Backend.Header())
+	p.write("<defines>", variant.Defines.Source())
+	if err := p.resolveIncludes(name, source, nil); err != nil {
+		return "", nil, err
+	}
+	return p.buf.String(), p.sourceMap, nil
+}
+
+type preprocessor struct {
+	buf       strings.Builder
+	sourceMap SourceMap
+}
+
+func (p *preprocessor) write(file, source string) {
+	for i, line := range splitLines(source) {
+		p.writeLine(Location{File: file, Line: i + 1}, line)
+	}
+}
+
+func (p *preprocessor) writeLine(location Location, line string) {
+	p.buf.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		p.buf.WriteByte('\n')
+	}
+	p.sourceMap = append(p.sourceMap, location)
+}
+
+func (p *preprocessor) resolveIncludes(file, source string, stack []string) error {
+	for i, line := range splitLines(source) {
+		var match = includePattern.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if match == nil {
+			p.writeLine(Location{File: file, Line: i + 1}, line)
+			continue
+		}
+		var name = match[1]
+		for _, included := range stack {
+			if included == name {
+				return fmt.Errorf("shader: %s:%d: recursive include of chunk %q", file, i+1, name)
+			}
+		}
+		chunk, ok := GetChunk(name)
+		if !ok {
+			return fmt.Errorf("shader: %s:%d: chunk %q not found", file, i+1, name)
+		}
+		if err := p.resolveIncludes(chunkFile(name), chunk, append(stack, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitLines(source string) []string {
+	if source == "" {
+		return nil
+	}
+	var lines = strings.SplitAfter(source, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,122 @@
+package shader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPreprocess(t *testing.T) {
+	RegisterChunk("test/a", "float a;\n#include <test/b>\n")
+	RegisterChunk("test/b", "float b;\n")
+	RegisterChunk("test/loop", "#include <test/loop2>\n")
+	RegisterChunk("test/loop2", "#include <test/loop>\n")
+
+	for _, tt := range []struct {
+		name    string
+		source  string
+		variant Variant
+		want    string
+		wantMap []string // want locations of output lines
+		wantErr string
+	}{
+		{
+			name:    "plain",
+			source:  "void main() {}\n",
+			want:    "#version 330 core\nvoid main() {}\n",
+			wantMap: []string{"<header>:1", "main:1"},
+		},
+		{
+			name:    "no trailing newline",
+			source:  "void main() {}",
+			want:    "#version 330 core\nvoid main() {}\n",
+			wantMap: []string{"<header>:1", "main:1"},
+		},
+		{
+			name:    "defines",
+			source:  "void main() {}\n",
+			variant: Variant{Defines: Defines{"USE_MAP": "", "COUNT": "2"}},
+			want:    "#version 330 core\n#define COUNT 2\n#define USE_MAP\nvoid main() {}\n",
+			wantMap: []string{"<header>:1", "<defines>:1", "<defines>:2", "main:1"},
+		},
+		{
+			name:    "es header",
+			source:  "void main() {}\n",
+			variant: Variant{Backend: GLSL300ES},
+			want:    "#version 300 es\nprecision highp float;\nprecision highp int;\nvoid main() {}\n",
+			wantMap: []string{"<header>:1", "<header>:2", "<header>:3", "main:1"},
+		},
+		{
+			name:    "nested includes",
+			source:  "#include <test/a>\n  # include <test/b>  \nvoid main() {}\n",
+			want:    "#version 330 core\nfloat a;\nfloat b;\nfloat b;\nvoid main() {}\n",
+			wantMap: []string{"<header>:1", "<chunk test/a>:1", "<chunk test/b>:1", "<chunk test/b>:1", "main:3"},
+		},
+		{
+			name:    "missing chunk",
+			source:  "\n#include <test/missing>\n",
+			wantErr: `main:2: chunk "test/missing" not found`,
+		},
+		{
+			name:    "recursive include",
+			source:  "#include <test/loop>\n",
+			wantErr: `recursive include of chunk "test/loop"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			output, sourceMap, err := PreprocessWithSourceMap("main", tt.source, tt.variant)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output != tt.want {
+				t.Fatalf("got output %q, want %q", output, tt.want)
+			}
+			var locations []string
+			for _, location := range sourceMap {
+				locations = append(locations, location.String())
+			}
+			if !reflect.DeepEqual(locations, tt.wantMap) {
+				t.Fatalf("got source map %v, want %v", locations, tt.wantMap)
+			}
+		})
+	}
+}
+
+func TestSourceMapLookup(t *testing.T) {
+	var sourceMap = SourceMap{{File: "a", Line: 1}, {File: "b", Line: 7}}
+	for _, tt := range []struct {
+		line   int
+		want   Location
+		wantOk bool
+	}{
+		{line: 0},
+		{line: 1, want: Location{File: "a", Line: 1}, wantOk: true},
+		{line: 2, want: Location{File: "b", Line: 7}, wantOk: true},
+		{line: 3},
+	} {
+		if got, ok := sourceMap.Lookup(tt.line); got != tt.want || ok != tt.wantOk {
+			t.Errorf("Lookup(%d) = %v, %v, want %v, %v", tt.line, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestDefinesKey(t *testing.T) {
+	for _, tt := range []struct {
+		defines Defines
+		want    string
+	}{
+		{want: ""},
+		{defines: Defines{"B": "", "A": "1"}, want: "A=1;B"},
+		{defines: Defines{"A": "1"}.With(Defines{"A": "2", "C": ""}), want: "A=2;C"},
+	} {
+		if got := tt.defines.Key(); got != tt.want {
+			t.Errorf("Key of %v = %q, want %q", tt.defines, got, tt.want)
+		}
+	}
+}
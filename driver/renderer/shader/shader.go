@@ -2,6 +2,7 @@ package shader
 
 import (
 	"bytes"
+	"strconv"
 	"text/template"
 )
 
@@ -9,8 +10,77 @@ type Uniform interface{}
 
 type Shader struct {
 	Uniforms map[string]Uniform
+	Defines  Defines
 	Vertex   string
 	Fragment string
+
+	// VertexFile and FragmentFile are optional paths of source files, they
+	// override Vertex and Fragment if present, see ReadFile
+	VertexFile   string
+	FragmentFile string
+
+	definesKey string // definesKey caches Defines.Key, see SetDefines
+}
+
+// SetDefines sets defines of the shader and caches the key of defines,
+// defines must not be modified after set
+func (shader *Shader) SetDefines(defines Defines) {
+	shader.Defines = defines
+	shader.definesKey = defines.Key()
+}
+
+// Resolve returns the shader with sources read from VertexFile and
+// FragmentFile
+func (shader Shader) Resolve() (Shader, error) {
+	var err error
+	if shader.VertexFile != "" {
+		if shader.Vertex, err = ReadFile(shader.VertexFile); err != nil {
+			return shader, err
+		}
+	}
+	if shader.FragmentFile != "" {
+		if shader.Fragment, err = ReadFile(shader.FragmentFile); err != nil {
+			return shader, err
+		}
+	}
+	return shader, nil
+}
+
+// Key returns a string which identifies defines of the shader and revision
+// of chunks it includes, it changes only if an included chunk is reloaded
+func (shader Shader) Key() string {
+	var key = shader.definesKey
+	if key == "" {
+		key = shader.Defines.Key()
+	}
+	var rev = includedRevision(shader.Vertex)
+	if r := includedRevision(shader.Fragment); r > rev {
+		rev = r
+	}
+	return key + "@" + strconv.Itoa(rev)
+}
+
+// Name returns name of the stage source used in source maps
+func (shader Shader) Name(stage Stage) string {
+	switch stage {
+	case VertexStage:
+		if shader.VertexFile != "" {
+			return shader.VertexFile
+		}
+	case FragmentStage:
+		if shader.FragmentFile != "" {
+			return shader.FragmentFile
+		}
+	}
+	return "<" + stage.String() + ">"
+}
+
+// Source returns source of the stage
+func (shader Shader) Source(stage Stage) string {
+	if stage == VertexStage {
+		return shader.Vertex
+	}
+	return shader.Fragment
 }
 
 func Template(source *template.Template, data interface{}) (string, error) {
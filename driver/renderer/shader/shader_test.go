@@ -0,0 +1,34 @@
+package shader
+
+import "testing"
+
+func TestShaderKey(t *testing.T) {
+	RegisterChunk("test/key_a", "#include <test/key_nested>\n")
+	RegisterChunk("test/key_nested", "float nested;\n")
+	RegisterChunk("test/key_b", "float b;\n")
+	var a = Shader{Vertex: "#include <test/key_a>\nvoid main() {}\n", Fragment: "void main() {}\n"}
+	var b = Shader{Vertex: "void main() {}\n", Fragment: "#include <test/key_b>\nvoid main() {}\n"}
+	a.SetDefines(Defines{"A": "1"})
+
+	for _, tt := range []struct {
+		name    string
+		chunk   string
+		source  string
+		changeA bool
+		changeB bool
+	}{
+		{name: "unchanged source", chunk: "test/key_b", source: "float b;\n"},
+		{name: "nested chunk", chunk: "test/key_nested", source: "float nested2;\n", changeA: true},
+		{name: "other chunk", chunk: "test/key_b", source: "float b2;\n", changeB: true},
+		{name: "unused chunk", chunk: "test/key_unused", source: "float unused;\n"},
+	} {
+		var keyA, keyB = a.Key(), b.Key()
+		RegisterChunk(tt.chunk, tt.source)
+		if got := a.Key() != keyA; got != tt.changeA {
+			t.Errorf("%s: key of a changed = %v, want %v", tt.name, got, tt.changeA)
+		}
+		if got := b.Key() != keyB; got != tt.changeB {
+			t.Errorf("%s: key of b changed = %v, want %v", tt.name, got, tt.changeB)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package renderer
+
+// CullFace specifies which faces are culled
+type CullFace int
+
+const (
+	CullBack CullFace = iota
+	CullFront
+	CullNone
+)
+
+// BlendEquation specifies how source and destination colors are combined
+type BlendEquation int
+
+const (
+	BlendAdd BlendEquation = iota
+	BlendSubtract
+	BlendReverseSubtract
+	BlendMin
+	BlendMax
+)
+
+// BlendFactor specifies the weight of source or destination color
+type BlendFactor int
+
+const (
+	BlendZero BlendFactor = iota
+	BlendOne
+	BlendSrcColor
+	BlendOneMinusSrcColor
+	BlendSrcAlpha
+	BlendOneMinusSrcAlpha
+	BlendDstAlpha
+	BlendOneMinusDstAlpha
+	BlendDstColor
+	BlendOneMinusDstColor
+	BlendSrcAlphaSaturate
+)
+
+// DepthFunc specifies the function used to compare depth values
+type DepthFunc int
+
+const (
+	DepthLessEqual DepthFunc = iota
+	DepthLess
+	DepthEqual
+	DepthGreaterEqual
+	DepthGreater
+	DepthNotEqual
+	DepthAlways
+	DepthNever
+)
+
+// BlendState holds blending configuration
+type BlendState struct {
+	Enabled       bool
+	Equation      BlendEquation
+	EquationAlpha BlendEquation
+	Src           BlendFactor
+	Dst           BlendFactor
+	SrcAlpha      BlendFactor
+	DstAlpha      BlendFactor
+}
+
+// DepthState holds depth buffer configuration
+type DepthState struct {
+	Test  bool
+	Write bool
+	Func  DepthFunc
+}
+
+// State represents pipeline state applied before drawing
+type State struct {
+	CullFace CullFace
+	Blend    BlendState
+	Depth    DepthState
+}
+
+// DefaultState returns the state of an opaque, front-sided material
+func DefaultState() State {
+	return State{
+		CullFace: CullBack,
+		Depth: DepthState{
+			Test:  true,
+			Write: true,
+			Func:  DepthLessEqual,
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package renderer
+
+import "time"
+
+// Stats holds counters of rendering a frame, and resources resident in GPU
+type Stats struct {
+	DrawCalls    int
+	Triangles    int
+	Lines        int
+	Points       int
+	ProgramBinds int // ProgramBinds is number of programs bound
+	StateChanges int // StateChanges is number of pipeline state changes submitted
+
+	Programs    int // Programs is number of resident programs
+	Buffers     int // Buffers is number of resident vertex, index and uniform buffers
+	BufferBytes int
+	Textures    int // Textures is number of resident textures
+	// TextureBytes is size of resident textures and renderbuffers computed
+	// from their formats, drivers may allocate more for alignment
+	TextureBytes int
+
+	// GPUTime is GPU time elapsed of the last frame whose timer query
+	// finished, it's zero if GPU timer is disabled
+	GPUTime time.Duration
+}
@@ -0,0 +1,149 @@
+package renderer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/gopherd/doge/math/tensor"
+	"github.com/gopherd/doge/operator"
+)
+
+var (
+	matrix2Type = reflect.TypeOf(tensor.Matrix2[float32]{})
+	matrix3Type = reflect.TypeOf(tensor.Matrix3[float32]{})
+	matrix4Type = reflect.TypeOf(tensor.Matrix4[float32]{})
+)
+
+// EncodeStd140 encodes value to bytes of uniform block with std140 layout.
+// The value must be a struct, fields are mapped to GLSL types as following:
+//
+//	bool, int32, uint32, float32         -> bool, int, uint, float
+//	int, uint                            -> int, uint, error if overflows 32 bits
+//	[2]T, [3]T, [4]T of above scalars    -> vecN, ivecN, uvecN, bvecN
+//	tensor.VectorN[float32]              -> vecN
+//	tensor.MatrixN[float32]              -> matN
+//	[N]T of other types, or tag `std140:"array"` -> T[N]
+//	struct                               -> struct
+//
+// Unexported fields and fields tagged by `std140:"-"` are skipped.
+func EncodeStd140(value any) ([]byte, error) {
+	var v = reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("renderer: uniform block must be a struct, got %T", value)
+	}
+	var e std140Encoder
+	if err := e.encode(v, false); err != nil {
+		return nil, fmt.Errorf("renderer: std140 %T: %w", value, err)
+	}
+	return e.buf, nil
+}
+
+type std140Encoder struct {
+	buf []byte
+}
+
+func (e *std140Encoder) pad(align int) {
+	for len(e.buf)%align != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *std140Encoder) putUint32(x uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], x)
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *std140Encoder) scalar(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		e.putUint32(operator.Bool[uint32](v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		var x = v.Int()
+		if x < math.MinInt32 || x > math.MaxInt32 {
+			return fmt.Errorf("value %d of %v overflows int32", x, v.Type())
+		}
+		e.putUint32(uint32(int32(x)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		var x = v.Uint()
+		if x > math.MaxUint32 {
+			return fmt.Errorf("value %d of %v overflows uint32", x, v.Type())
+		}
+		e.putUint32(uint32(x))
+	case reflect.Float32:
+		e.putUint32(math.Float32bits(float32(v.Float())))
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+	return nil
+}
+
+func isStd140Scalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Float32:
+		return true
+	}
+	return false
+}
+
+func isStd140Vector(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Len() >= 2 && t.Len() <= 4 && isStd140Scalar(t.Elem())
+}
+
+func (e *std140Encoder) encode(v reflect.Value, array bool) error {
+	var t = v.Type()
+	switch {
+	case t == matrix2Type, t == matrix3Type, t == matrix4Type:
+		// matrices are stored as arrays of column vectors aligned to vec4
+		var dim = int(math.Sqrt(float64(t.Len())))
+		e.pad(16)
+		for j := 0; j < dim; j++ {
+			for i := 0; i < dim; i++ {
+				if err := e.scalar(v.Index(i + j*dim)); err != nil {
+					return err
+				}
+			}
+			e.pad(16)
+		}
+	case isStd140Scalar(t):
+		e.pad(4)
+		return e.scalar(v)
+	case !array && isStd140Vector(t):
+		e.pad(operator.If(t.Len() == 2, 8, 16))
+		for i := 0; i < v.Len(); i++ {
+			if err := e.scalar(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case t.Kind() == reflect.Array:
+		// array elements are aligned to vec4
+		e.pad(16)
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encode(v.Index(i), false); err != nil {
+				return err
+			}
+			e.pad(16)
+		}
+	case t.Kind() == reflect.Struct:
+		e.pad(16)
+		for i := 0; i < t.NumField(); i++ {
+			var field = t.Field(i)
+			var tag = field.Tag.Get("std140")
+			if !field.IsExported() || tag == "-" {
+				continue
+			}
+			if err := e.encode(v.Field(i), tag == "array"); err != nil {
+				return fmt.Errorf("%s: %w", field.Name, err)
+			}
+		}
+		e.pad(16)
+	default:
+		return fmt.Errorf("unsupported type %v", t)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package renderer
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gopherd/doge/math/tensor"
+)
+
+// word returns bits of a float32 as a word of std140 buffer
+func word(x float32) uint32 { return math.Float32bits(x) }
+
+func words(b []byte) []uint32 {
+	var w = make([]uint32, len(b)/4)
+	for i := range w {
+		w[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	return w
+}
+
+func TestEncodeStd140(t *testing.T) {
+	type light struct {
+		Color     [3]float32
+		Intensity float32
+	}
+	for _, tt := range []struct {
+		name    string
+		value   any
+		want    []uint32
+		wantErr string
+	}{
+		{
+			name: "scalars",
+			value: struct {
+				A bool
+				B int32
+				C uint
+				D float32
+			}{true, -1, 2, 0.5},
+			want: []uint32{1, 0xffffffff, 2, word(0.5)},
+		},
+		{
+			name: "vec3 aligned to 16 bytes",
+			value: struct {
+				A float32
+				B [3]float32
+				C float32
+			}{1, [3]float32{2, 3, 4}, 5},
+			want: []uint32{word(1), 0, 0, 0, word(2), word(3), word(4), word(5)},
+		},
+		{
+			name: "vec2 aligned to 8 bytes",
+			value: struct {
+				A float32
+				B tensor.Vector2[float32]
+			}{1, tensor.Vector2[float32]{2, 3}},
+			want: []uint32{word(1), 0, word(2), word(3)},
+		},
+		{
+			name: "mat3 columns padded",
+			value: struct {
+				M tensor.Matrix3[float32]
+			}{tensor.Matrix3[float32]{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+			want: []uint32{word(1), word(2), word(3), 0, word(4), word(5), word(6), 0, word(7), word(8), word(9), 0},
+		},
+		{
+			name: "float array elements aligned to vec4",
+			value: struct {
+				A [2]float32 `std140:"array"`
+			}{[2]float32{1, 2}},
+			want: []uint32{word(1), 0, 0, 0, word(2), 0, 0, 0},
+		},
+		{
+			name: "array of structs",
+			value: struct {
+				Lights [2]light
+				Count  int
+			}{[2]light{{[3]float32{1, 1, 1}, 2}, {[3]float32{0, 0, 1}, 3}}, 2},
+			want: []uint32{word(1), word(1), word(1), word(2), 0, 0, word(1), word(3), 2, 0, 0, 0},
+		},
+		{
+			name: "skipped fields",
+			value: &struct {
+				A float32
+				b float32
+				C float32 `std140:"-"`
+				D float32
+			}{A: 1, b: 2, C: 3, D: 4},
+			want: []uint32{word(1), word(4), 0, 0},
+		},
+		{
+			name:    "not a struct",
+			value:   1,
+			wantErr: "must be a struct",
+		},
+		{
+			name:    "unsupported type",
+			value:   struct{ A float64 }{},
+			wantErr: "A: unsupported type float64",
+		},
+		{
+			name:    "int overflows",
+			value:   struct{ A int }{math.MaxInt32 + 1},
+			wantErr: "overflows int32",
+		},
+		{
+			name:    "uint overflows",
+			value:   struct{ A uint }{math.MaxUint32 + 1},
+			wantErr: "overflows uint32",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeStd140(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := words(data); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package renderer
+
+// DrawMode specifies primitives to render
+type DrawMode int
+
+const (
+	Triangles DrawMode = iota
+	TriangleStrip
+	TriangleFan
+	Lines
+	LineStrip
+	LineLoop
+	Points
+)
+
+// primitives returns number of primitives drawn by count vertices
+func (mode DrawMode) primitives(count int) (triangles, lines, points int) {
+	switch mode {
+	case Triangles:
+		triangles = count / 3
+	case TriangleStrip, TriangleFan:
+		if count >= 3 {
+			triangles = count - 2
+		}
+	case Lines:
+		lines = count / 2
+	case LineStrip:
+		if count >= 2 {
+			lines = count - 1
+		}
+	case LineLoop:
+		if count >= 2 {
+			lines = count
+		}
+	case Points:
+		points = count
+	}
+	return
+}
+
+// VertexAttribute holds data of a vertex attribute uploaded to GPU
+type VertexAttribute struct {
+	Location uint32 // Location of attribute in program
+	Size     int    // Size is number of components per vertex, 1 to 4
+	Data     any    // Data is a slice of int8, int16, int32, uint8, uint16, uint32, float32 or float64
+}
@@ -0,0 +1,10 @@
+//go:build headless
+
+package window
+
+// Default creates the default window of the platform, builds tagged
+// headless have no GLFW driver so it's a HeadlessWindow running until
+// closed
+func Default() Window {
+	return Headless(0)
+}
@@ -0,0 +1,37 @@
+package window
+
+import "github.com/gopherd/three/core/event"
+
+type (
+	// ResizeEvent is fired when size or content scale of window changed
+	ResizeEvent struct {
+		Width             int // Width of window in screen coordinates
+		Height            int // Height of window in screen coordinates
+		FramebufferWidth  int // FramebufferWidth of window in pixels
+		FramebufferHeight int // FramebufferHeight of window in pixels
+		ContentScaleX     float32
+		ContentScaleY     float32
+	}
+
+	// DropEvent is fired when files are dropped on window
+	DropEvent struct {
+		Paths []string
+	}
+)
+
+// Aspect returns width to height ratio of framebuffer
+func (e ResizeEvent) Aspect() float32 {
+	if e.FramebufferHeight == 0 {
+		return 1
+	}
+	return float32(e.FramebufferWidth) / float32(e.FramebufferHeight)
+}
+
+//@mod:final
+var (
+	ResizeEventType = event.TypeOf[*ResizeEvent](nil)
+	DropEventType   = event.TypeOf[*DropEvent](nil)
+)
+
+func (ResizeEvent) Type() event.Type { return ResizeEventType }
+func (DropEvent) Type() event.Type   { return DropEventType }
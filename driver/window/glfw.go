@@ -1,36 +1,173 @@
+//go:build !headless
+
 package window
 
 import (
+	"image"
+
 	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/gopherd/doge/operator"
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/input/device"
 
 	"github.com/gopherd/three/driver/renderer"
 )
 
 type glfwindow struct {
-	window *glfw.Window
+	window  *glfw.Window
+	handler func(event.Event)
+	cursor  struct {
+		x, y  float64
+		image *glfw.Cursor
+	}
+	monitor  int // monitor is index of monitor for fullscreen
+	windowed struct {
+		x, y, width, height int // position and size restored from fullscreen
+	}
 }
 
 func GLFWindow() Window {
 	return &glfwindow{}
 }
 
-func (w *glfwindow) Init(renderer renderer.Renderer, title string, width, height int) error {
+// Default creates the default window of the platform, it's a GLFW window
+// unless built with tag headless
+func Default() Window {
+	return GLFWindow()
+}
+
+func (w *glfwindow) Init(renderer renderer.Renderer, options Options) error {
+	options.init()
 	if err := glfw.Init(); err != nil {
 		return err
 	}
-	window, err := glfw.CreateWindow(width, height, title, nil, nil)
+	glfw.DefaultWindowHints()
+	glfw.WindowHint(glfw.Resizable, operator.If(options.FixedSize, glfw.False, glfw.True))
+	glfw.WindowHint(glfw.Decorated, operator.If(options.Undecorated, glfw.False, glfw.True))
+	glfw.WindowHint(glfw.Visible, operator.If(options.Hidden, glfw.False, glfw.True))
+	glfw.WindowHint(glfw.Samples, options.Samples)
+	glfw.WindowHint(glfw.ContextVersionMajor, options.GLMajor)
+	glfw.WindowHint(glfw.ContextVersionMinor, options.GLMinor)
+	if options.GLCompatProfile {
+		glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCompatProfile)
+	} else {
+		glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+		glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+	}
+	glfw.WindowHint(glfw.OpenGLDebugContext, operator.If(options.GLDebug, glfw.True, glfw.False))
+	glfw.WindowHint(glfw.ScaleToMonitor, operator.If(options.DisableHiDPI, glfw.False, glfw.True))
+	glfw.WindowHint(glfw.CocoaRetinaFramebuffer, operator.If(options.DisableHiDPI, glfw.False, glfw.True))
+
+	w.monitor = options.Monitor
+	var monitor *glfw.Monitor
+	var width, height = options.Width, options.Height
+	if options.Fullscreen {
+		if monitor = w.selectedMonitor(); monitor != nil {
+			if mode := monitor.GetVideoMode(); mode != nil {
+				width, height = mode.Width, mode.Height
+			}
+		}
+	}
+	window, err := glfw.CreateWindow(width, height, options.Title, monitor, nil)
 	if err != nil {
+		// Terminate of Window is not called if Init fails
+		glfw.Terminate()
 		return err
 	}
 	window.MakeContextCurrent()
 	w.window = window
+	w.windowed.width, w.windowed.height = options.Width, options.Height
+	w.SetVSync(!options.DisableVSync)
+	core.LogWindow.Info("window created", "width", width, "height", height, "fullscreen", monitor != nil)
 	w.window.SetFramebufferSizeCallback(func(_ *glfw.Window, width, height int) {
 		renderer.Viewport(0, 0, int32(width), int32(height))
+		w.emitResize()
 	})
+	w.window.SetSizeCallback(func(_ *glfw.Window, width, height int) {
+		w.emitResize()
+	})
+	w.window.SetContentScaleCallback(func(_ *glfw.Window, x, y float32) {
+		w.emitResize()
+	})
+	w.cursor.x, w.cursor.y = window.GetCursorPos()
+	w.window.SetKeyCallback(w.onKey)
+	w.window.SetCharCallback(w.onChar)
+	w.window.SetMouseButtonCallback(w.onMouseButton)
+	w.window.SetCursorPosCallback(w.onCursorPos)
+	w.window.SetCursorEnterCallback(w.onCursorEnter)
+	w.window.SetScrollCallback(w.onScroll)
+	w.window.SetDropCallback(w.onDrop)
 	return nil
 }
 
+// SetEventHandler sets the handler of events, a ResizeEvent of current
+// size is fired immediately
+func (w *glfwindow) SetEventHandler(handler func(event.Event)) {
+	w.handler = handler
+	w.emitResize()
+}
+
+func (w *glfwindow) emitResize() {
+	var e ResizeEvent
+	e.Width, e.Height = w.window.GetSize()
+	e.FramebufferWidth, e.FramebufferHeight = w.window.GetFramebufferSize()
+	e.ContentScaleX, e.ContentScaleY = w.window.GetContentScale()
+	w.emit(e)
+}
+
+func (w *glfwindow) emit(e event.Event) {
+	if w.handler != nil {
+		w.handler(e)
+	}
+}
+
+func (w *glfwindow) onKey(_ *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	w.emit(device.KeyEvent{
+		Key:      device.Key(key),
+		Scancode: scancode,
+		Action:   device.Action(action),
+		Mods:     device.ModifierKey(mods),
+	})
+}
+
+func (w *glfwindow) onChar(_ *glfw.Window, char rune) {
+	w.emit(device.CharEvent{Char: char})
+}
+
+func (w *glfwindow) onMouseButton(_ *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	w.emit(device.MouseButtonEvent{
+		Button: device.MouseButton(button),
+		Action: device.Action(action),
+		Mods:   device.ModifierKey(mods),
+		X:      w.cursor.x,
+		Y:      w.cursor.y,
+	})
+}
+
+func (w *glfwindow) onCursorPos(_ *glfw.Window, x, y float64) {
+	var dx, dy = x - w.cursor.x, y - w.cursor.y
+	w.cursor.x, w.cursor.y = x, y
+	w.emit(device.MouseMoveEvent{X: x, Y: y, DX: dx, DY: dy})
+}
+
+func (w *glfwindow) onCursorEnter(_ *glfw.Window, entered bool) {
+	w.emit(device.CursorEnterEvent{Entered: entered})
+}
+
+func (w *glfwindow) onScroll(_ *glfw.Window, dx, dy float64) {
+	w.emit(device.ScrollEvent{DX: dx, DY: dy})
+}
+
+func (w *glfwindow) onDrop(_ *glfw.Window, names []string) {
+	w.emit(DropEvent{Paths: names})
+}
+
 func (w *glfwindow) Terminate() {
+	if w.cursor.image != nil {
+		w.cursor.image.Destroy()
+		w.cursor.image = nil
+	}
 	glfw.Terminate()
 }
 
@@ -42,3 +179,170 @@ func (w *glfwindow) Update() {
 func (w *glfwindow) ShouldClose() bool {
 	return w.window.ShouldClose()
 }
+
+func (w *glfwindow) SetTitle(title string) {
+	w.window.SetTitle(title)
+}
+
+func (w *glfwindow) SetIcon(images []image.Image) {
+	w.window.SetIcon(images)
+}
+
+func (w *glfwindow) GetSize() (width, height int) {
+	return w.window.GetSize()
+}
+
+func (w *glfwindow) SetSize(width, height int) {
+	w.window.SetSize(width, height)
+}
+
+func (w *glfwindow) GetFramebufferSize() (width, height int) {
+	return w.window.GetFramebufferSize()
+}
+
+func (w *glfwindow) GetPosition() (x, y int) {
+	return w.window.GetPos()
+}
+
+func (w *glfwindow) SetPosition(x, y int) {
+	w.window.SetPos(x, y)
+}
+
+func (w *glfwindow) Monitors() []Monitor {
+	var monitors = glfw.GetMonitors()
+	var result = make([]Monitor, 0, len(monitors))
+	for _, m := range monitors {
+		var monitor = Monitor{Name: m.GetName()}
+		monitor.X, monitor.Y = m.GetPos()
+		if mode := m.GetVideoMode(); mode != nil {
+			monitor.Width = mode.Width
+			monitor.Height = mode.Height
+			monitor.RefreshRate = mode.RefreshRate
+		}
+		result = append(result, monitor)
+	}
+	return result
+}
+
+// selectedMonitor returns the monitor selected for fullscreen, or the
+// primary monitor if index is out of range
+func (w *glfwindow) selectedMonitor() *glfw.Monitor {
+	var monitors = glfw.GetMonitors()
+	if w.monitor >= 0 && w.monitor < len(monitors) {
+		return monitors[w.monitor]
+	}
+	return glfw.GetPrimaryMonitor()
+}
+
+func (w *glfwindow) SetMonitor(index int) {
+	w.monitor = index
+	if w.IsFullscreen() {
+		w.enterFullscreen()
+	}
+}
+
+func (w *glfwindow) IsFullscreen() bool {
+	return w.window.GetMonitor() != nil
+}
+
+func (w *glfwindow) SetFullscreen(fullscreen bool) {
+	if fullscreen == w.IsFullscreen() {
+		return
+	}
+	if fullscreen {
+		w.windowed.x, w.windowed.y = w.window.GetPos()
+		w.windowed.width, w.windowed.height = w.window.GetSize()
+		w.enterFullscreen()
+	} else {
+		var windowed = w.windowed
+		w.window.SetMonitor(nil, windowed.x, windowed.y, windowed.width, windowed.height, glfw.DontCare)
+	}
+}
+
+func (w *glfwindow) enterFullscreen() {
+	var monitor = w.selectedMonitor()
+	if monitor == nil {
+		return
+	}
+	var mode = monitor.GetVideoMode()
+	if mode == nil {
+		return
+	}
+	w.window.SetMonitor(monitor, 0, 0, mode.Width, mode.Height, mode.RefreshRate)
+}
+
+func (w *glfwindow) SetCursorMode(mode CursorMode) {
+	switch mode {
+	case CursorHidden:
+		w.window.SetInputMode(glfw.CursorMode, glfw.CursorHidden)
+	case CursorLocked:
+		w.window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	default:
+		w.window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	}
+	if glfw.RawMouseMotionSupported() {
+		w.window.SetInputMode(glfw.RawMouseMotion, operator.Bool[int](mode == CursorLocked))
+	}
+}
+
+func (w *glfwindow) SetCursorImage(img image.Image, hotX, hotY int) {
+	var cursor *glfw.Cursor
+	if img != nil {
+		cursor = glfw.CreateCursor(img, hotX, hotY)
+	}
+	w.window.SetCursor(cursor)
+	if w.cursor.image != nil {
+		w.cursor.image.Destroy()
+	}
+	w.cursor.image = cursor
+}
+
+func (w *glfwindow) ClipboardString() string {
+	return w.window.GetClipboardString()
+}
+
+func (w *glfwindow) SetClipboardString(s string) {
+	w.window.SetClipboardString(s)
+}
+
+func (w *glfwindow) GamepadSource() device.GamepadSource {
+	return glfwGamepads{}
+}
+
+// glfwGamepads implements device.GamepadSource by joysticks with gamepad
+// mappings of GLFW
+type glfwGamepads struct{}
+
+// Gamepads implements device.GamepadSource Gamepads method
+func (glfwGamepads) Gamepads() []int {
+	var ids []int
+	for joystick := glfw.Joystick1; joystick <= glfw.JoystickLast; joystick++ {
+		if joystick.Present() && joystick.IsGamepad() {
+			ids = append(ids, int(joystick))
+		}
+	}
+	return ids
+}
+
+// GamepadName implements device.GamepadSource GamepadName method
+func (glfwGamepads) GamepadName(id int) string {
+	return glfw.Joystick(id).GetGamepadName()
+}
+
+// GamepadState implements device.GamepadSource GamepadState method
+func (glfwGamepads) GamepadState(id int) (device.GamepadState, bool) {
+	var state device.GamepadState
+	var raw = glfw.Joystick(id).GetGamepadState()
+	if raw == nil {
+		return state, false
+	}
+	for i := range state.Buttons {
+		state.Buttons[i] = raw.Buttons[i] == glfw.Press
+	}
+	copy(state.Axes[:], raw.Axes[:])
+	return state, true
+}
+
+func (w *glfwindow) SetVSync(enabled bool) {
+	glfw.SwapInterval(operator.Bool[int](enabled))
+}
@@ -0,0 +1,241 @@
+package window
+
+import (
+	"image"
+
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/input/device"
+)
+
+var _ Window = (*HeadlessWindow)(nil)
+
+// HeadlessWindow implements Window without opening a display, e.g. for
+// integration tests on CI. It creates no graphics context, so it pairs
+// with renderer.NullRenderer which draws nothing and reads blank pixels.
+// Rendering images requires Offscreen and a display. Build with tag
+// headless to drop the GLFW driver and its cgo dependencies.
+type HeadlessWindow struct {
+	options   Options
+	frames    int // frames is number of frames to run, unlimited if not positive
+	frame     int
+	closed    bool
+	handler   func(event.Event)
+	pending   []event.Event
+	gamepads  device.GamepadSource
+	clipboard string
+	x, y      int
+
+	fullscreen bool
+	monitor    int
+	windowed   struct {
+		width, height int
+	}
+}
+
+// Headless creates a HeadlessWindow which closes after frames updates, it
+// runs until Close called if frames is not positive
+func Headless(frames int) *HeadlessWindow {
+	return &HeadlessWindow{frames: frames}
+}
+
+// Init implements Window Init method
+func (w *HeadlessWindow) Init(renderer renderer.Renderer, options Options) error {
+	options.init()
+	w.options = options
+	w.monitor = options.Monitor
+	w.windowed.width, w.windowed.height = options.Width, options.Height
+	w.fullscreen = options.Fullscreen
+	if w.fullscreen {
+		var monitor = w.Monitors()[0]
+		w.options.Width, w.options.Height = monitor.Width, monitor.Height
+	}
+	return nil
+}
+
+// Terminate implements Window Terminate method
+func (w *HeadlessWindow) Terminate() {
+	w.closed = true
+}
+
+// Update implements Window Update method, events emitted since the last
+// update are delivered to the handler
+func (w *HeadlessWindow) Update() {
+	w.frame++
+	var pending = w.pending
+	w.pending = nil
+	for _, e := range pending {
+		w.deliver(e)
+	}
+}
+
+// ShouldClose implements Window ShouldClose method
+func (w *HeadlessWindow) ShouldClose() bool {
+	return w.closed || (w.frames > 0 && w.frame >= w.frames)
+}
+
+// Close makes the window close before the next frame
+func (w *HeadlessWindow) Close() {
+	w.closed = true
+}
+
+// Frame returns number of frames updated
+func (w *HeadlessWindow) Frame() int {
+	return w.frame
+}
+
+// Emit queues the event which is delivered on the next update, e.g. to
+// simulate input in tests
+func (w *HeadlessWindow) Emit(e event.Event) {
+	w.pending = append(w.pending, e)
+}
+
+func (w *HeadlessWindow) deliver(e event.Event) {
+	if w.handler != nil {
+		w.handler(e)
+	}
+}
+
+func (w *HeadlessWindow) emitResize() {
+	w.deliver(ResizeEvent{
+		Width:             w.options.Width,
+		Height:            w.options.Height,
+		FramebufferWidth:  w.options.Width,
+		FramebufferHeight: w.options.Height,
+		ContentScaleX:     1,
+		ContentScaleY:     1,
+	})
+}
+
+// SetVSync implements Window SetVSync method
+func (w *HeadlessWindow) SetVSync(enabled bool) {
+	w.options.DisableVSync = !enabled
+}
+
+// SetEventHandler implements Window SetEventHandler method, a ResizeEvent
+// of current size is fired immediately
+func (w *HeadlessWindow) SetEventHandler(handler func(event.Event)) {
+	w.handler = handler
+	w.emitResize()
+}
+
+// SetGamepadSource sets the source of gamepads, e.g. a fake source in tests
+func (w *HeadlessWindow) SetGamepadSource(source device.GamepadSource) {
+	w.gamepads = source
+}
+
+// GamepadSource implements Window GamepadSource method, no gamepads are
+// connected unless a source set by SetGamepadSource
+func (w *HeadlessWindow) GamepadSource() device.GamepadSource {
+	if w.gamepads == nil {
+		return noGamepads{}
+	}
+	return w.gamepads
+}
+
+// SetTitle implements Window SetTitle method
+func (w *HeadlessWindow) SetTitle(title string) {
+	w.options.Title = title
+}
+
+// Title returns title of window
+func (w *HeadlessWindow) Title() string {
+	return w.options.Title
+}
+
+// SetIcon implements Window SetIcon method
+func (w *HeadlessWindow) SetIcon(images []image.Image) {}
+
+// GetSize implements Window GetSize method
+func (w *HeadlessWindow) GetSize() (width, height int) {
+	return w.options.Width, w.options.Height
+}
+
+// SetSize implements Window SetSize method, a ResizeEvent is fired
+func (w *HeadlessWindow) SetSize(width, height int) {
+	if width == w.options.Width && height == w.options.Height {
+		return
+	}
+	w.options.Width, w.options.Height = width, height
+	w.emitResize()
+}
+
+// GetFramebufferSize implements Window GetFramebufferSize method
+func (w *HeadlessWindow) GetFramebufferSize() (width, height int) {
+	return w.options.Width, w.options.Height
+}
+
+// GetPosition implements Window GetPosition method
+func (w *HeadlessWindow) GetPosition() (x, y int) {
+	return w.x, w.y
+}
+
+// SetPosition implements Window SetPosition method
+func (w *HeadlessWindow) SetPosition(x, y int) {
+	w.x, w.y = x, y
+}
+
+// Monitors implements Window Monitors method, there is a virtual monitor
+// of the initial size of window
+func (w *HeadlessWindow) Monitors() []Monitor {
+	return []Monitor{{
+		Name:        "headless",
+		Width:       w.windowed.width,
+		Height:      w.windowed.height,
+		RefreshRate: 60,
+	}}
+}
+
+// SetMonitor implements Window SetMonitor method
+func (w *HeadlessWindow) SetMonitor(index int) {
+	w.monitor = index
+}
+
+// IsFullscreen implements Window IsFullscreen method
+func (w *HeadlessWindow) IsFullscreen() bool {
+	return w.fullscreen
+}
+
+// SetFullscreen implements Window SetFullscreen method
+func (w *HeadlessWindow) SetFullscreen(fullscreen bool) {
+	if fullscreen == w.fullscreen {
+		return
+	}
+	w.fullscreen = fullscreen
+	if fullscreen {
+		var monitor = w.Monitors()[0]
+		w.SetSize(monitor.Width, monitor.Height)
+	} else {
+		w.SetSize(w.windowed.width, w.windowed.height)
+	}
+}
+
+// SetCursorMode implements Window SetCursorMode method
+func (w *HeadlessWindow) SetCursorMode(mode CursorMode) {}
+
+// SetCursorImage implements Window SetCursorImage method
+func (w *HeadlessWindow) SetCursorImage(img image.Image, hotX, hotY int) {}
+
+// ClipboardString implements Window ClipboardString method
+func (w *HeadlessWindow) ClipboardString() string {
+	return w.clipboard
+}
+
+// SetClipboardString implements Window SetClipboardString method
+func (w *HeadlessWindow) SetClipboardString(s string) {
+	w.clipboard = s
+}
+
+// noGamepads implements device.GamepadSource without gamepads
+type noGamepads struct{}
+
+// Gamepads implements device.GamepadSource Gamepads method
+func (noGamepads) Gamepads() []int { return nil }
+
+// GamepadName implements device.GamepadSource GamepadName method
+func (noGamepads) GamepadName(id int) string { return "" }
+
+// GamepadState implements device.GamepadSource GamepadState method
+func (noGamepads) GamepadState(id int) (device.GamepadState, bool) {
+	return device.GamepadState{}, false
+}
@@ -0,0 +1,41 @@
+//go:build !headless
+
+package window
+
+import "github.com/gopherd/three/driver/renderer"
+
+// offscreenWindow is a hidden GLFW window which provides an OpenGL context
+// without showing anything, and closes after a number of frames
+type offscreenWindow struct {
+	glfwindow
+	frames int // frames is number of frames to run, unlimited if not positive
+	frame  int
+}
+
+// Offscreen creates a hidden Window with an OpenGL context which closes
+// after frames updates, it runs until closed otherwise. It pairs with
+// renderer.OffscreenRenderer for batch rendering. The context is created by
+// GLFW, so a display connection is still required, e.g. Xvfb on CI, there
+// is no EGL or OSMesa context for machines without display.
+func Offscreen(frames int) Window {
+	return &offscreenWindow{frames: frames}
+}
+
+// Init implements Window Init method
+func (w *offscreenWindow) Init(renderer renderer.Renderer, options Options) error {
+	options.Hidden = true
+	options.Fullscreen = false
+	options.FixedSize = true
+	return w.glfwindow.Init(renderer, options)
+}
+
+// Update implements Window Update method
+func (w *offscreenWindow) Update() {
+	w.frame++
+	w.glfwindow.Update()
+}
+
+// ShouldClose implements Window ShouldClose method
+func (w *offscreenWindow) ShouldClose() bool {
+	return w.glfwindow.ShouldClose() || (w.frames > 0 && w.frame >= w.frames)
+}
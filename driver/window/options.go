@@ -0,0 +1,63 @@
+package window
+
+import "github.com/gopherd/doge/operator"
+
+// Options configures creating window
+type Options struct {
+	Title  string
+	Width  int // Width of window in screen coordinates, default 800
+	Height int // Height of window in screen coordinates, default 600
+
+	// FixedSize disallows resizing window by user
+	FixedSize bool
+	// Undecorated creates window without border and title bar
+	Undecorated bool
+	// Hidden creates window invisible, its OpenGL context is still usable
+	// for rendering into render targets
+	Hidden bool
+	// Samples is number of samples per pixel of multisampling, 0 disables it
+	Samples int
+	// GLMajor and GLMinor are version of OpenGL context, default 3.3
+	GLMajor, GLMinor int
+	// GLCompatProfile requests compatibility profile instead of core profile
+	GLCompatProfile bool
+	// GLDebug creates a debug OpenGL context whose debug messages are
+	// forwarded to the logger, debug output is disabled otherwise
+	GLDebug bool
+	// DisableVSync disables synchronizing buffer swaps with monitor refresh
+	DisableVSync bool
+	// DisableHiDPI disables scaling window by content scale of monitor and
+	// full resolution framebuffers on Retina displays
+	DisableHiDPI bool
+	// Fullscreen creates window in fullscreen on Monitor
+	Fullscreen bool
+	// Monitor is index of monitor for fullscreen, 0 is the primary monitor
+	Monitor int
+}
+
+func (options *Options) init() {
+	options.Title = operator.Or(options.Title, "Title")
+	options.Width = operator.Or(options.Width, 800)
+	options.Height = operator.Or(options.Height, 600)
+	if options.GLMajor == 0 {
+		options.GLMajor, options.GLMinor = 3, 3
+	}
+}
+
+// Monitor describes a connected monitor
+type Monitor struct {
+	Name        string
+	X, Y        int // X, Y is position of monitor in screen coordinates
+	Width       int // Width of current video mode in pixels
+	Height      int // Height of current video mode in pixels
+	RefreshRate int // RefreshRate of current video mode in Hz
+}
+
+// CursorMode specifies visibility and behavior of cursor
+type CursorMode int
+
+const (
+	CursorNormal CursorMode = iota // CursorNormal shows cursor normally
+	CursorHidden                   // CursorHidden hides cursor while it's over window
+	CursorLocked                   // CursorLocked hides cursor and locks it to window, movement is unbounded
+)
@@ -1,10 +1,60 @@
 package window
 
-import "github.com/gopherd/three/driver/renderer"
+import (
+	"image"
+
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/input/device"
+)
 
 type Window interface {
-	Init(renderer renderer.Renderer, title string, width, height int) error
+	Init(renderer renderer.Renderer, options Options) error
 	Terminate()
 	Update()
 	ShouldClose() bool
+	// SetVSync enables or disables synchronizing buffer swaps with monitor refresh
+	SetVSync(enabled bool)
+	// SetEventHandler sets the handler which receives input events
+	SetEventHandler(handler func(event.Event))
+	// GamepadSource returns the source of gamepad states
+	GamepadSource() device.GamepadSource
+
+	// SetTitle sets title of window
+	SetTitle(title string)
+	// SetIcon sets candidate images of icon, the closest size is used, nil
+	// restores the default icon
+	SetIcon(images []image.Image)
+	// GetSize returns size of window in screen coordinates
+	GetSize() (width, height int)
+	// SetSize sets size of window in screen coordinates
+	SetSize(width, height int)
+	// GetFramebufferSize returns size of framebuffer in pixels
+	GetFramebufferSize() (width, height int)
+	// GetPosition returns position of window in screen coordinates
+	GetPosition() (x, y int)
+	// SetPosition sets position of window in screen coordinates
+	SetPosition(x, y int)
+
+	// Monitors returns connected monitors, the first is the primary monitor
+	Monitors() []Monitor
+	// SetMonitor selects the monitor of index for fullscreen, the window
+	// moves to the monitor if it's fullscreen
+	SetMonitor(index int)
+	// IsFullscreen reports whether the window is fullscreen
+	IsFullscreen() bool
+	// SetFullscreen switches between fullscreen and windowed mode, size and
+	// position of windowed mode are restored
+	SetFullscreen(fullscreen bool)
+
+	// SetCursorMode sets visibility and behavior of cursor
+	SetCursorMode(mode CursorMode)
+	// SetCursorImage sets image of cursor with hotspot, nil restores the
+	// default cursor
+	SetCursorImage(img image.Image, hotX, hotY int)
+
+	// ClipboardString returns content of clipboard as string
+	ClipboardString() string
+	// SetClipboardString sets content of clipboard
+	SetClipboardString(s string)
 }
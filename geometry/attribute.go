@@ -14,6 +14,7 @@ const (
 type Attribute interface {
 	Count() int
 	Stride() int
+	Data() any // Data returns the underlying slice of attribute
 	NeedsUpdate() bool
 	SetNeedsUpdate(bool)
 	Int8(offset int) int8
@@ -50,6 +51,15 @@ func (attribute BufferAttribute[T]) Stride() int {
 	return attribute.stride
 }
 
+func (attribute BufferAttribute[T]) Data() any {
+	return attribute.data
+}
+
+// Slice returns the underlying slice of attribute
+func (attribute BufferAttribute[T]) Slice() []T {
+	return attribute.data
+}
+
 func (attribute *BufferAttribute[T]) NeedsUpdate() bool {
 	return !attribute.notNeedsUpdate
 }
@@ -2,8 +2,10 @@ package geometry
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/gopherd/doge/math/mathutil"
+	"github.com/gopherd/doge/operator"
 
 	"github.com/gopherd/three/core"
 )
@@ -12,6 +14,12 @@ type Box3 struct {
 	Min, Max core.Vector3
 }
 
+// EmptyBox3 returns an empty box, the union of it and a box is the box
+func EmptyBox3() Box3 {
+	var inf = core.Float(math.Inf(1))
+	return Box3{Min: core.Vec3(inf, inf, inf), Max: core.Vec3(-inf, -inf, -inf)}
+}
+
 func (box Box3) Center() core.Vector3 { return box.Min.Add(box.Max).Div(2) }
 func (box Box3) Size() core.Vector3   { return box.Max.Sub(box.Min) }
 
@@ -69,6 +77,25 @@ func (box Box3) Union(other Box3) Box3 {
 	return Box3{Min: min, Max: max}
 }
 
+// ApplyMatrix4 returns the box bounding the box transformed by m, an empty
+// box stays empty
+func (box Box3) ApplyMatrix4(m core.Matrix4) Box3 {
+	if box.IsEmpty() {
+		return box
+	}
+	var result = EmptyBox3()
+	for i := 0; i < 8; i++ {
+		var corner = core.Vec3(
+			operator.If(i&1 == 0, box.Min.X(), box.Max.X()),
+			operator.If(i&2 == 0, box.Min.Y(), box.Max.Y()),
+			operator.If(i&4 == 0, box.Min.Z(), box.Max.Z()),
+		)
+		var p = m.DotVec3(corner)
+		result = result.Union(Box3{Min: p, Max: p})
+	}
+	return result
+}
+
 func (box Box3) IntersectsBox(other Box3) bool {
 	return !(other.Max.X() < box.Min.X() || other.Min.X() > box.Max.X() ||
 		other.Max.Y() < box.Min.Y() || other.Min.Y() > box.Max.Y() ||
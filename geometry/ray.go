@@ -0,0 +1,89 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/gopherd/doge/math/mathutil"
+
+	"github.com/gopherd/three/core"
+)
+
+const rayEpsilon = 1e-6
+
+type Ray struct {
+	Origin, Direction core.Vector3
+}
+
+func (ray Ray) At(t core.Float) core.Vector3 { return ray.Direction.Mul(t).Add(ray.Origin) }
+
+// ApplyMatrix4 transforms the ray by matrix, direction of the result is
+// normalized
+func (ray Ray) ApplyMatrix4(mat core.Matrix4) Ray {
+	var origin = transformPoint(mat, ray.Origin)
+	var target = transformPoint(mat, ray.Origin.Add(ray.Direction))
+	return Ray{Origin: origin, Direction: target.Sub(origin).Normalize()}
+}
+
+// IntersectBox returns distance from origin to the nearest intersection
+// point with box
+func (ray Ray) IntersectBox(box Box3) (t core.Float, ok bool) {
+	var tmin, tmax core.Float = 0, core.Float(math.Inf(1))
+	for i := 0; i < 3; i++ {
+		var origin, direction = ray.Origin[i], ray.Direction[i]
+		if mathutil.Abs(direction) < rayEpsilon {
+			if origin < box.Min[i] || origin > box.Max[i] {
+				return 0, false
+			}
+			continue
+		}
+		var t1 = (box.Min[i] - origin) / direction
+		var t2 = (box.Max[i] - origin) / direction
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tmin = mathutil.Max(tmin, t1)
+		tmax = mathutil.Min(tmax, t2)
+		if tmin > tmax {
+			return 0, false
+		}
+	}
+	return tmin, true
+}
+
+// IntersectTriangle returns distance from origin to the intersection point
+// with triangle abc, triangles facing away from the ray are ignored if
+// backfaceCulling is true
+func (ray Ray) IntersectTriangle(a, b, c core.Vector3, backfaceCulling bool) (t core.Float, ok bool) {
+	var edge1 = b.Sub(a)
+	var edge2 = c.Sub(a)
+	var p = ray.Direction.Cross(edge2)
+	var det = edge1.Dot(p)
+	if backfaceCulling && det < rayEpsilon {
+		return 0, false
+	}
+	if mathutil.Abs(det) < rayEpsilon {
+		return 0, false
+	}
+	var invDet = 1 / det
+	var s = ray.Origin.Sub(a)
+	var u = s.Dot(p) * invDet
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	var q = s.Cross(edge1)
+	var v = ray.Direction.Dot(q) * invDet
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	t = edge2.Dot(q) * invDet
+	return t, t >= 0
+}
+
+// transformPoint transforms point by matrix with perspective divide
+func transformPoint(mat core.Matrix4, point core.Vector3) core.Vector3 {
+	var v = mat.DotVec4(point.Vec4())
+	if w := v.W(); w != 0 && w != 1 {
+		return core.Vec3(v.X()/w, v.Y()/w, v.Z()/w)
+	}
+	return v.Vec3()
+}
@@ -0,0 +1,437 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/gopherd/doge/operator"
+	"github.com/gopherd/three/core/event"
+)
+
+// Control represents kind of a physical control bound to actions
+type Control int
+
+const (
+	ControlKey           Control = iota // ControlKey binds a keyboard key
+	ControlMouseButton                  // ControlMouseButton binds a mouse button
+	ControlGamepadButton                // ControlGamepadButton binds a button of any gamepad
+	ControlGamepadAxis                  // ControlGamepadAxis binds an axis of any gamepad
+)
+
+var controlNames = [...]string{
+	ControlKey:           "key",
+	ControlMouseButton:   "mouse",
+	ControlGamepadButton: "gamepad_button",
+	ControlGamepadAxis:   "gamepad_axis",
+}
+
+// String implements fmt.Stringer String method
+func (control Control) String() string {
+	if control >= 0 && int(control) < len(controlNames) {
+		return controlNames[control]
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler MarshalText method
+func (control Control) MarshalText() ([]byte, error) {
+	if control < 0 || int(control) >= len(controlNames) {
+		return nil, fmt.Errorf("input: unknown control %d", int(control))
+	}
+	return []byte(controlNames[control]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler UnmarshalText method
+func (control *Control) UnmarshalText(text []byte) error {
+	for i, name := range controlNames {
+		if name == string(text) {
+			*control = Control(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("input: unknown control %q", text)
+}
+
+// Binding binds a control to an action or an axis
+type Binding struct {
+	Control Control `json:"control"`
+	// Code is the key, mouse button, gamepad button or gamepad axis
+	Code int `json:"code"`
+	// Mods are modifiers which must be held exactly for keys and mouse
+	// buttons, modifiers are ignored if zero. Caps lock, num lock, and
+	// modifier keys bound by the binding itself are ignored.
+	Mods ModifierKey `json:"mods,omitempty"`
+	// Chord are keys which must be held together with the control
+	Chord []Key `json:"chord,omitempty"`
+	// Scale scales value of the control for axes, 1 if zero
+	Scale float32 `json:"scale,omitempty"`
+}
+
+// KeyBinding returns a binding of key with modifiers
+func KeyBinding(key Key, mods ModifierKey) Binding {
+	return Binding{Control: ControlKey, Code: int(key), Mods: mods}
+}
+
+// MouseBinding returns a binding of mouse button with modifiers
+func MouseBinding(button MouseButton, mods ModifierKey) Binding {
+	return Binding{Control: ControlMouseButton, Code: int(button), Mods: mods}
+}
+
+// GamepadButtonBinding returns a binding of gamepad button
+func GamepadButtonBinding(button GamepadButton) Binding {
+	return Binding{Control: ControlGamepadButton, Code: int(button)}
+}
+
+// GamepadAxisBinding returns a binding of gamepad axis scaled by scale
+func GamepadAxisBinding(axis GamepadAxis, scale float32) Binding {
+	return Binding{Control: ControlGamepadAxis, Code: int(axis), Scale: scale}
+}
+
+// WithScale returns a copy of binding with scale
+func (b Binding) WithScale(scale float32) Binding {
+	b.Scale = scale
+	return b
+}
+
+// WithChord returns a copy of binding which requires keys to be held
+func (b Binding) WithChord(keys ...Key) Binding {
+	b.Chord = append(append([]Key(nil), b.Chord...), keys...)
+	return b
+}
+
+// axisThreshold is the value an axis must exceed to trigger actions
+const axisThreshold = 0.5
+
+const exactMods = ModShift | ModControl | ModAlt | ModSuper
+
+// modifierOf returns the modifier of key, 0 if key isn't a modifier key
+func modifierOf(key Key) ModifierKey {
+	switch key {
+	case KeyLeftShift, KeyRightShift:
+		return ModShift
+	case KeyLeftControl, KeyRightControl:
+		return ModControl
+	case KeyLeftAlt, KeyRightAlt:
+		return ModAlt
+	case KeyLeftSuper, KeyRightSuper:
+		return ModSuper
+	}
+	return 0
+}
+
+// heldMods returns modifiers held down, it's derived from keys since mods
+// of events are only updated by key and mouse button events
+func heldMods(state *State) ModifierKey {
+	var mods ModifierKey
+	for key := KeyLeftShift; key <= KeyRightSuper; key++ {
+		if state.IsKeyDown(key) {
+			mods |= modifierOf(key)
+		}
+	}
+	return mods
+}
+
+// ownMods returns modifiers of the bound key and chord keys, they are held
+// by the binding itself and not matched against Mods
+func (b Binding) ownMods() ModifierKey {
+	var mods ModifierKey
+	if b.Control == ControlKey {
+		mods |= modifierOf(Key(b.Code))
+	}
+	for _, key := range b.Chord {
+		mods |= modifierOf(key)
+	}
+	return mods
+}
+
+// matchMods reports whether held modifiers match Mods of the binding
+func (b Binding) matchMods(mods ModifierKey) bool {
+	return b.Mods&exactMods == 0 || mods&^b.ownMods() == b.Mods&exactMods
+}
+
+// value returns value of the binding in [-1, 1] before scaled
+func (b Binding) value(state *State, mods ModifierKey) float32 {
+	for _, key := range b.Chord {
+		if !state.IsKeyDown(key) {
+			return 0
+		}
+	}
+	switch b.Control {
+	case ControlKey:
+		return operator.Bool[float32](b.matchMods(mods) && state.IsKeyDown(Key(b.Code)))
+	case ControlMouseButton:
+		return operator.Bool[float32](b.matchMods(mods) && state.IsMouseButtonDown(MouseButton(b.Code)))
+	case ControlGamepadButton:
+		for id := range state.gamepads {
+			if state.IsGamepadButtonDown(id, GamepadButton(b.Code)) {
+				return 1
+			}
+		}
+	case ControlGamepadAxis:
+		var result float32
+		for id := range state.gamepads {
+			var value = state.GamepadAxis(id, GamepadAxis(b.Code))
+			if math.Abs(float64(value)) > math.Abs(float64(result)) {
+				result = value
+			}
+		}
+		return result
+	}
+	return 0
+}
+
+// ActionEvent is fired when a bound action of an active context is pressed
+// or released
+type ActionEvent struct {
+	Context *ActionContext
+	Action  string
+	Pressed bool
+}
+
+//@mod:final
+var ActionEventType = event.TypeOf[*ActionEvent](nil)
+
+func (ActionEvent) Type() event.Type { return ActionEventType }
+
+type actionState struct {
+	bindings []Binding
+	down     bool
+	previous bool
+}
+
+// ActionContext maps controls to named actions and axes, e.g. a context
+// per scene. It's evaluated once per frame by Update while it's active.
+type ActionContext struct {
+	name    string
+	actions map[string]*actionState
+	axes    map[string][]Binding
+	values  map[string]float32
+}
+
+// NewActionContext creates an empty ActionContext
+func NewActionContext(name string) *ActionContext {
+	return &ActionContext{
+		name:    name,
+		actions: make(map[string]*actionState),
+		axes:    make(map[string][]Binding),
+		values:  make(map[string]float32),
+	}
+}
+
+// Name returns name of the context
+func (ctx *ActionContext) Name() string {
+	return ctx.name
+}
+
+// Bind appends bindings to the action
+func (ctx *ActionContext) Bind(action string, bindings ...Binding) {
+	var s, ok = ctx.actions[action]
+	if !ok {
+		s = &actionState{}
+		ctx.actions[action] = s
+	}
+	s.bindings = append(s.bindings, bindings...)
+}
+
+// Rebind replaces bindings of the action
+func (ctx *ActionContext) Rebind(action string, bindings ...Binding) {
+	if s, ok := ctx.actions[action]; ok {
+		s.bindings = append(s.bindings[:0:0], bindings...)
+		return
+	}
+	ctx.Bind(action, bindings...)
+}
+
+// Unbind removes the action
+func (ctx *ActionContext) Unbind(action string) {
+	delete(ctx.actions, action)
+}
+
+// Bindings returns bindings of the action
+func (ctx *ActionContext) Bindings(action string) []Binding {
+	if s, ok := ctx.actions[action]; ok {
+		return s.bindings
+	}
+	return nil
+}
+
+// Actions returns sorted names of actions
+func (ctx *ActionContext) Actions() []string {
+	var names = make([]string, 0, len(ctx.actions))
+	for name := range ctx.actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BindAxis appends bindings to the axis, value of axis is sum of scaled
+// values of bindings clamped to [-1, 1]
+func (ctx *ActionContext) BindAxis(axis string, bindings ...Binding) {
+	ctx.axes[axis] = append(ctx.axes[axis], bindings...)
+}
+
+// RebindAxis replaces bindings of the axis
+func (ctx *ActionContext) RebindAxis(axis string, bindings ...Binding) {
+	ctx.axes[axis] = append([]Binding(nil), bindings...)
+}
+
+// UnbindAxis removes the axis
+func (ctx *ActionContext) UnbindAxis(axis string) {
+	delete(ctx.axes, axis)
+	delete(ctx.values, axis)
+}
+
+// AxisBindings returns bindings of the axis
+func (ctx *ActionContext) AxisBindings(axis string) []Binding {
+	return ctx.axes[axis]
+}
+
+// IsActionDown reports whether the action is held down
+func (ctx *ActionContext) IsActionDown(action string) bool {
+	s, ok := ctx.actions[action]
+	return ok && s.down
+}
+
+// IsActionPressed reports whether the action was pressed in last update
+func (ctx *ActionContext) IsActionPressed(action string) bool {
+	s, ok := ctx.actions[action]
+	return ok && s.down && !s.previous
+}
+
+// IsActionReleased reports whether the action was released in last update
+func (ctx *ActionContext) IsActionReleased(action string) bool {
+	s, ok := ctx.actions[action]
+	return ok && !s.down && s.previous
+}
+
+// Axis returns value of the axis in [-1, 1]
+func (ctx *ActionContext) Axis(axis string) float32 {
+	return ctx.values[axis]
+}
+
+// Update evaluates actions and axes by input state, events of pressed and
+// released actions are returned to be dispatched in no particular order
+func (ctx *ActionContext) Update(state *State) []event.Event {
+	var events []event.Event
+	var mods = heldMods(state)
+	for name, s := range ctx.actions {
+		s.previous = s.down
+		s.down = false
+		for _, b := range s.bindings {
+			if b.value(state, mods)*operator.Or(b.Scale, 1) > axisThreshold {
+				s.down = true
+				break
+			}
+		}
+		if s.down != s.previous {
+			events = append(events, ActionEvent{Context: ctx, Action: name, Pressed: s.down})
+		}
+	}
+	for name, bindings := range ctx.axes {
+		var value float32
+		for _, b := range bindings {
+			value += b.value(state, mods) * operator.Or(b.Scale, 1)
+		}
+		ctx.values[name] = float32(math.Max(-1, math.Min(1, float64(value))))
+	}
+	return events
+}
+
+// Reset releases all actions and zeroes all axes without firing events,
+// e.g. when the context is deactivated
+func (ctx *ActionContext) Reset() {
+	for _, s := range ctx.actions {
+		s.down = false
+		s.previous = false
+	}
+	for name := range ctx.values {
+		delete(ctx.values, name)
+	}
+}
+
+type actionContextJSON struct {
+	Actions map[string][]Binding `json:"actions"`
+	Axes    map[string][]Binding `json:"axes"`
+}
+
+// MarshalJSON implements json.Marshaler MarshalJSON method
+func (ctx *ActionContext) MarshalJSON() ([]byte, error) {
+	var data = actionContextJSON{
+		Actions: make(map[string][]Binding, len(ctx.actions)),
+		Axes:    ctx.axes,
+	}
+	for name, s := range ctx.actions {
+		data.Actions[name] = s.bindings
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler UnmarshalJSON method, bindings
+// of actions and axes in data replace existing bindings
+func (ctx *ActionContext) UnmarshalJSON(data []byte) error {
+	var v actionContextJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if ctx.actions == nil {
+		ctx.actions = make(map[string]*actionState)
+		ctx.axes = make(map[string][]Binding)
+		ctx.values = make(map[string]float32)
+	}
+	for name, bindings := range v.Actions {
+		ctx.Rebind(name, bindings...)
+	}
+	for name, bindings := range v.Axes {
+		ctx.RebindAxis(name, bindings...)
+	}
+	return nil
+}
+
+// Save writes bindings of the context as JSON to w
+func (ctx *ActionContext) Save(w io.Writer) error {
+	var encoder = json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(ctx)
+}
+
+// Load reads bindings of the context as JSON from r
+func (ctx *ActionContext) Load(r io.Reader) error {
+	return json.NewDecoder(r).Decode(ctx)
+}
+
+// CaptureBinding returns binding of a control pressed in last frame, it's
+// used to rebind actions by asking user to press a control. Modifier keys
+// held down are captured as modifiers instead of controls, a modifier key
+// is captured as the control if it's released without any other control
+// pressed.
+func CaptureBinding(state *State) (Binding, bool) {
+	var mods = heldMods(state)
+	for key := Key(0); key <= KeyLast; key++ {
+		if modifierOf(key) != 0 || !state.IsKeyPressed(key) {
+			continue
+		}
+		return KeyBinding(key, mods), true
+	}
+	for button := MouseButton(0); button <= MouseButtonLast; button++ {
+		if state.IsMouseButtonPressed(button) {
+			return MouseBinding(button, mods), true
+		}
+	}
+	for _, id := range state.Gamepads() {
+		for button := GamepadButton(0); button <= ButtonLast; button++ {
+			if state.IsGamepadButtonPressed(id, button) {
+				return GamepadButtonBinding(button), true
+			}
+		}
+	}
+	for key := KeyLeftShift; key <= KeyRightSuper; key++ {
+		if state.IsKeyReleased(key) {
+			return KeyBinding(key, mods), true
+		}
+	}
+	return Binding{}, false
+}
@@ -0,0 +1,173 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestActionContextJSON(t *testing.T) {
+	var ctx = NewActionContext("game")
+	ctx.Bind("jump", KeyBinding(KeySpace, 0), GamepadButtonBinding(ButtonA))
+	ctx.Bind("save", KeyBinding(KeyS, ModControl))
+	ctx.Bind("fire", MouseBinding(MouseButtonLeft, 0).WithChord(KeyLeftAlt))
+	ctx.BindAxis("move_x", KeyBinding(KeyD, 0), KeyBinding(KeyA, 0).WithScale(-1), GamepadAxisBinding(AxisLeftX, 1))
+
+	for _, tt := range []struct {
+		name      string
+		roundTrip func(*ActionContext) (*ActionContext, error)
+	}{
+		{
+			name: "json",
+			roundTrip: func(ctx *ActionContext) (*ActionContext, error) {
+				data, err := json.Marshal(ctx)
+				if err != nil {
+					return nil, err
+				}
+				var loaded = NewActionContext(ctx.Name())
+				return loaded, json.Unmarshal(data, loaded)
+			},
+		},
+		{
+			name: "save and load",
+			roundTrip: func(ctx *ActionContext) (*ActionContext, error) {
+				var buf bytes.Buffer
+				if err := ctx.Save(&buf); err != nil {
+					return nil, err
+				}
+				var loaded = NewActionContext(ctx.Name())
+				return loaded, loaded.Load(&buf)
+			},
+		},
+		{
+			name: "zero value",
+			roundTrip: func(ctx *ActionContext) (*ActionContext, error) {
+				data, err := json.Marshal(ctx)
+				if err != nil {
+					return nil, err
+				}
+				var loaded ActionContext
+				return &loaded, json.Unmarshal(data, &loaded)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := tt.roundTrip(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := loaded.Actions(), ctx.Actions(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("actions = %v, want %v", got, want)
+			}
+			for _, action := range ctx.Actions() {
+				if got, want := loaded.Bindings(action), ctx.Bindings(action); !reflect.DeepEqual(got, want) {
+					t.Errorf("bindings of %q = %+v, want %+v", action, got, want)
+				}
+			}
+			if got, want := loaded.AxisBindings("move_x"), ctx.AxisBindings("move_x"); !reflect.DeepEqual(got, want) {
+				t.Errorf("bindings of axis = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestActionContextUnmarshalErrors(t *testing.T) {
+	for _, data := range []string{
+		`{`,
+		`{"actions": {"jump": [{"control": "joystick", "code": 1}]}}`,
+		`{"actions": {"jump": [{"control": 1}]}}`,
+	} {
+		if err := json.Unmarshal([]byte(data), NewActionContext("game")); err == nil {
+			t.Errorf("unmarshal %s: want error", data)
+		}
+	}
+}
+
+func TestBindingMods(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		binding Binding
+		held    []Key
+		want    bool
+	}{
+		{name: "no mods", binding: KeyBinding(KeyS, 0), held: []Key{KeyS}, want: true},
+		{name: "any mods", binding: KeyBinding(KeyS, 0), held: []Key{KeyLeftShift, KeyS}, want: true},
+		{name: "exact mods", binding: KeyBinding(KeyS, ModControl), held: []Key{KeyRightControl, KeyS}, want: true},
+		{name: "missing mods", binding: KeyBinding(KeyS, ModControl), held: []Key{KeyS}},
+		{name: "extra mods", binding: KeyBinding(KeyS, ModControl), held: []Key{KeyLeftControl, KeyLeftShift, KeyS}},
+		{name: "lock mods ignored", binding: KeyBinding(KeyS, ModControl|ModCapsLock), held: []Key{KeyLeftControl, KeyS}, want: true},
+		{name: "bound modifier key", binding: KeyBinding(KeyLeftShift, ModControl), held: []Key{KeyLeftControl, KeyLeftShift}, want: true},
+		{name: "chord modifier key", binding: KeyBinding(KeyS, ModControl).WithChord(KeyLeftAlt), held: []Key{KeyLeftControl, KeyLeftAlt, KeyS}, want: true},
+		{name: "chord not held", binding: KeyBinding(KeyS, 0).WithChord(KeyLeftAlt), held: []Key{KeyS}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var state State
+			for _, key := range tt.held {
+				state.Handle(KeyEvent{Key: key, Action: Press})
+			}
+			state.Update()
+			var ctx = NewActionContext("game")
+			ctx.Bind("action", tt.binding)
+			var events = ctx.Update(&state)
+			if got := ctx.IsActionDown("action"); got != tt.want {
+				t.Fatalf("down = %v, want %v", got, tt.want)
+			}
+			if got := len(events) == 1 && events[0].(ActionEvent).Pressed; got != tt.want {
+				t.Fatalf("got events %v, want pressed %v", events, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaptureBinding(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		frames   [][]KeyEvent
+		want     Binding
+		captured bool
+	}{
+		{name: "nothing"},
+		{name: "key", frames: [][]KeyEvent{{{Key: KeyS, Action: Press}}}, want: KeyBinding(KeyS, 0), captured: true},
+		{
+			name:     "key with modifier",
+			frames:   [][]KeyEvent{{{Key: KeyLeftControl, Action: Press}}, {{Key: KeyS, Action: Press}}},
+			want:     KeyBinding(KeyS, ModControl),
+			captured: true,
+		},
+		{name: "modifier held", frames: [][]KeyEvent{{{Key: KeyLeftShift, Action: Press}}}},
+		{
+			name:     "modifier released",
+			frames:   [][]KeyEvent{{{Key: KeyLeftShift, Action: Press}}, {{Key: KeyLeftShift, Action: Release}}},
+			want:     KeyBinding(KeyLeftShift, 0),
+			captured: true,
+		},
+		{
+			name: "modifier released with other modifier",
+			frames: [][]KeyEvent{
+				{{Key: KeyLeftControl, Action: Press}, {Key: KeyLeftShift, Action: Press}},
+				{{Key: KeyLeftShift, Action: Release}},
+			},
+			want:     KeyBinding(KeyLeftShift, ModControl),
+			captured: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var state State
+			var got Binding
+			var ok bool
+			for _, frame := range tt.frames {
+				for _, e := range frame {
+					state.Handle(e)
+				}
+				state.Update()
+				if got, ok = CaptureBinding(&state); ok {
+					break
+				}
+			}
+			if ok != tt.captured || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, %v, want %+v, %v", got, ok, tt.want, tt.captured)
+			}
+		})
+	}
+}
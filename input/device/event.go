@@ -0,0 +1,59 @@
+package device
+
+import "github.com/gopherd/three/core/event"
+
+type (
+	// KeyEvent is fired when a key is pressed, repeated or released
+	KeyEvent struct {
+		Key      Key
+		Scancode int // Scancode is platform-specific code of the key
+		Action   Action
+		Mods     ModifierKey
+	}
+
+	// CharEvent is fired when a unicode character is input
+	CharEvent struct {
+		Char rune
+	}
+
+	// MouseButtonEvent is fired when a mouse button is pressed or released
+	MouseButtonEvent struct {
+		Button MouseButton
+		Action Action
+		Mods   ModifierKey
+		X, Y   float64 // X, Y is cursor position in screen coordinates
+	}
+
+	// MouseMoveEvent is fired when the cursor moves
+	MouseMoveEvent struct {
+		X, Y   float64 // X, Y is cursor position in screen coordinates
+		DX, DY float64 // DX, DY is offset from the previous position
+	}
+
+	// ScrollEvent is fired when mouse wheel or touchpad scrolls
+	ScrollEvent struct {
+		DX, DY float64
+	}
+
+	// CursorEnterEvent is fired when the cursor enters or leaves the window
+	CursorEnterEvent struct {
+		Entered bool
+	}
+)
+
+//@mod:final
+var (
+	KeyEventType         = event.TypeOf[*KeyEvent](nil)
+	CharEventType        = event.TypeOf[*CharEvent](nil)
+	MouseButtonEventType = event.TypeOf[*MouseButtonEvent](nil)
+	MouseMoveEventType   = event.TypeOf[*MouseMoveEvent](nil)
+	ScrollEventType      = event.TypeOf[*ScrollEvent](nil)
+	CursorEnterEventType = event.TypeOf[*CursorEnterEvent](nil)
+)
+
+func (KeyEvent) Type() event.Type         { return KeyEventType }
+func (CharEvent) Type() event.Type        { return CharEventType }
+func (MouseButtonEvent) Type() event.Type { return MouseButtonEventType }
+func (MouseMoveEvent) Type() event.Type   { return MouseMoveEventType }
+func (ScrollEvent) Type() event.Type      { return ScrollEventType }
+func (CursorEnterEvent) Type() event.Type { return CursorEnterEventType }
@@ -0,0 +1,62 @@
+package device
+
+// GamepadButton represents a button of gamepad with standard mapping,
+// values are the same as GLFW gamepad button tokens
+type GamepadButton int
+
+const (
+	ButtonA GamepadButton = iota
+	ButtonB
+	ButtonX
+	ButtonY
+	ButtonLeftBumper
+	ButtonRightBumper
+	ButtonBack
+	ButtonStart
+	ButtonGuide
+	ButtonLeftThumb
+	ButtonRightThumb
+	ButtonDpadUp
+	ButtonDpadRight
+	ButtonDpadDown
+	ButtonDpadLeft
+
+	ButtonLast     = ButtonDpadLeft
+	ButtonCross    = ButtonA
+	ButtonCircle   = ButtonB
+	ButtonSquare   = ButtonX
+	ButtonTriangle = ButtonY
+)
+
+// GamepadAxis represents an axis of gamepad with standard mapping, values
+// are the same as GLFW gamepad axis tokens
+type GamepadAxis int
+
+const (
+	AxisLeftX GamepadAxis = iota
+	AxisLeftY
+	AxisRightX
+	AxisRightY
+	AxisLeftTrigger
+	AxisRightTrigger
+
+	AxisLast = AxisRightTrigger
+)
+
+// GamepadState holds state of a gamepad, sticks are in [-1, 1] and
+// triggers are in [0, 1] after dead zones applied
+type GamepadState struct {
+	Buttons [ButtonLast + 1]bool
+	Axes    [AxisLast + 1]float32
+}
+
+// GamepadSource provides raw states of gamepads, triggers of raw states
+// are in [-1, 1] as reported by GLFW
+type GamepadSource interface {
+	// Gamepads returns ids of connected gamepads
+	Gamepads() []int
+	// GamepadName returns human-readable name of the gamepad
+	GamepadName(id int) string
+	// GamepadState returns raw state of the gamepad
+	GamepadState(id int) (GamepadState, bool)
+}
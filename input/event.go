@@ -0,0 +1,24 @@
+package input
+
+import "github.com/gopherd/three/input/device"
+
+// Events of keyboard and mouse are defined in the leaf package device
+// shared with window drivers
+type (
+	KeyEvent         = device.KeyEvent
+	CharEvent        = device.CharEvent
+	MouseButtonEvent = device.MouseButtonEvent
+	MouseMoveEvent   = device.MouseMoveEvent
+	ScrollEvent      = device.ScrollEvent
+	CursorEnterEvent = device.CursorEnterEvent
+)
+
+//@mod:final
+var (
+	KeyEventType         = device.KeyEventType
+	CharEventType        = device.CharEventType
+	MouseButtonEventType = device.MouseButtonEventType
+	MouseMoveEventType   = device.MouseMoveEventType
+	ScrollEventType      = device.ScrollEventType
+	CursorEnterEventType = device.CursorEnterEventType
+)
@@ -0,0 +1,218 @@
+package input
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/input/device"
+)
+
+// Gamepad buttons, axes, states and sources are defined in the leaf package
+// device shared with window drivers
+type (
+	GamepadButton = device.GamepadButton
+	GamepadAxis   = device.GamepadAxis
+	GamepadState  = device.GamepadState
+	GamepadSource = device.GamepadSource
+)
+
+const (
+	ButtonA           = device.ButtonA
+	ButtonB           = device.ButtonB
+	ButtonX           = device.ButtonX
+	ButtonY           = device.ButtonY
+	ButtonLeftBumper  = device.ButtonLeftBumper
+	ButtonRightBumper = device.ButtonRightBumper
+	ButtonBack        = device.ButtonBack
+	ButtonStart       = device.ButtonStart
+	ButtonGuide       = device.ButtonGuide
+	ButtonLeftThumb   = device.ButtonLeftThumb
+	ButtonRightThumb  = device.ButtonRightThumb
+	ButtonDpadUp      = device.ButtonDpadUp
+	ButtonDpadRight   = device.ButtonDpadRight
+	ButtonDpadDown    = device.ButtonDpadDown
+	ButtonDpadLeft    = device.ButtonDpadLeft
+	ButtonLast        = device.ButtonLast
+	ButtonCross       = device.ButtonCross
+	ButtonCircle      = device.ButtonCircle
+	ButtonSquare      = device.ButtonSquare
+	ButtonTriangle    = device.ButtonTriangle
+	AxisLeftX         = device.AxisLeftX
+	AxisLeftY         = device.AxisLeftY
+	AxisRightX        = device.AxisRightX
+	AxisRightY        = device.AxisRightY
+	AxisLeftTrigger   = device.AxisLeftTrigger
+	AxisRightTrigger  = device.AxisRightTrigger
+	AxisLast          = device.AxisLast
+)
+
+type (
+	// GamepadConnectedEvent is fired when a gamepad is connected
+	GamepadConnectedEvent struct {
+		ID   int
+		Name string
+	}
+
+	// GamepadDisconnectedEvent is fired when a gamepad is disconnected
+	GamepadDisconnectedEvent struct {
+		ID int
+	}
+)
+
+//@mod:final
+var (
+	GamepadConnectedEventType    = event.TypeOf[*GamepadConnectedEvent](nil)
+	GamepadDisconnectedEventType = event.TypeOf[*GamepadDisconnectedEvent](nil)
+)
+
+func (GamepadConnectedEvent) Type() event.Type    { return GamepadConnectedEventType }
+func (GamepadDisconnectedEvent) Type() event.Type { return GamepadDisconnectedEventType }
+
+const (
+	defaultStickDeadZone   = 0.15
+	defaultTriggerDeadZone = 0.05
+)
+
+type gamepad struct {
+	name     string
+	current  GamepadState
+	previous GamepadState
+}
+
+// SetDeadZones sets dead zones of sticks and triggers, defaults are 0.15
+// and 0.05
+func (state *State) SetDeadZones(stick, trigger float32) {
+	state.deadZones.custom = true
+	state.deadZones.stick = stick
+	state.deadZones.trigger = trigger
+}
+
+// DeadZones returns dead zones of sticks and triggers
+func (state *State) DeadZones() (stick, trigger float32) {
+	if !state.deadZones.custom {
+		return defaultStickDeadZone, defaultTriggerDeadZone
+	}
+	return state.deadZones.stick, state.deadZones.trigger
+}
+
+// PollGamepads polls states of gamepads from source, connect and
+// disconnect events are returned to be dispatched
+func (state *State) PollGamepads(source GamepadSource) []event.Event {
+	var events []event.Event
+	var connected = make(map[int]bool)
+	for _, id := range source.Gamepads() {
+		raw, ok := source.GamepadState(id)
+		if !ok {
+			continue
+		}
+		connected[id] = true
+		pad, ok := state.gamepads[id]
+		if !ok {
+			if state.gamepads == nil {
+				state.gamepads = make(map[int]*gamepad)
+			}
+			pad = &gamepad{name: source.GamepadName(id)}
+			state.gamepads[id] = pad
+			events = append(events, GamepadConnectedEvent{ID: id, Name: pad.name})
+			core.LogInput.Info("gamepad connected", "id", id, "name", pad.name)
+		}
+		pad.previous = pad.current
+		pad.current = state.applyDeadZones(raw)
+	}
+	for id := range state.gamepads {
+		if !connected[id] {
+			delete(state.gamepads, id)
+			events = append(events, GamepadDisconnectedEvent{ID: id})
+			core.LogInput.Info("gamepad disconnected", "id", id)
+		}
+	}
+	return events
+}
+
+func (state *State) applyDeadZones(raw GamepadState) GamepadState {
+	var stick, trigger = state.DeadZones()
+	var result = raw
+	result.Axes[AxisLeftX], result.Axes[AxisLeftY] = radialDeadZone(raw.Axes[AxisLeftX], raw.Axes[AxisLeftY], stick)
+	result.Axes[AxisRightX], result.Axes[AxisRightY] = radialDeadZone(raw.Axes[AxisRightX], raw.Axes[AxisRightY], stick)
+	result.Axes[AxisLeftTrigger] = linearDeadZone((raw.Axes[AxisLeftTrigger]+1)/2, trigger)
+	result.Axes[AxisRightTrigger] = linearDeadZone((raw.Axes[AxisRightTrigger]+1)/2, trigger)
+	return result
+}
+
+// radialDeadZone zeroes the stick within dead zone and rescales the rest
+// to keep the output continuous
+func radialDeadZone(x, y, deadZone float32) (float32, float32) {
+	var length = float32(math.Hypot(float64(x), float64(y)))
+	if length <= deadZone {
+		return 0, 0
+	}
+	var scale = (math.Min(float64(length), 1) - float64(deadZone)) / (1 - float64(deadZone)) / float64(length)
+	return x * float32(scale), y * float32(scale)
+}
+
+func linearDeadZone(value, deadZone float32) float32 {
+	if value <= deadZone {
+		return 0
+	}
+	return float32(math.Min(float64((value-deadZone)/(1-deadZone)), 1))
+}
+
+// Gamepads returns ids of connected gamepads
+func (state *State) Gamepads() []int {
+	var ids = make([]int, 0, len(state.gamepads))
+	for id := range state.gamepads {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Gamepad returns state of the gamepad
+func (state *State) Gamepad(id int) (GamepadState, bool) {
+	pad, ok := state.gamepads[id]
+	if !ok {
+		return GamepadState{}, false
+	}
+	return pad.current, true
+}
+
+// GamepadName returns name of the gamepad
+func (state *State) GamepadName(id int) string {
+	if pad, ok := state.gamepads[id]; ok {
+		return pad.name
+	}
+	return ""
+}
+
+// IsGamepadButtonDown reports whether the button of gamepad is held down
+func (state *State) IsGamepadButtonDown(id int, button GamepadButton) bool {
+	pad, ok := state.gamepads[id]
+	return ok && button >= 0 && button <= ButtonLast && pad.current.Buttons[button]
+}
+
+// IsGamepadButtonPressed reports whether the button of gamepad was pressed
+// since the previous poll
+func (state *State) IsGamepadButtonPressed(id int, button GamepadButton) bool {
+	pad, ok := state.gamepads[id]
+	return ok && button >= 0 && button <= ButtonLast &&
+		pad.current.Buttons[button] && !pad.previous.Buttons[button]
+}
+
+// IsGamepadButtonReleased reports whether the button of gamepad was
+// released since the previous poll
+func (state *State) IsGamepadButtonReleased(id int, button GamepadButton) bool {
+	pad, ok := state.gamepads[id]
+	return ok && button >= 0 && button <= ButtonLast &&
+		!pad.current.Buttons[button] && pad.previous.Buttons[button]
+}
+
+// GamepadAxis returns value of the axis of gamepad with dead zones applied
+func (state *State) GamepadAxis(id int, axis GamepadAxis) float32 {
+	pad, ok := state.gamepads[id]
+	if !ok || axis < 0 || axis > AxisLast {
+		return 0
+	}
+	return pad.current.Axes[axis]
+}
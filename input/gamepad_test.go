@@ -0,0 +1,147 @@
+package input
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// fakeGamepads implements GamepadSource by states set in tests
+type fakeGamepads struct {
+	names  map[int]string
+	states map[int]GamepadState
+}
+
+func (f *fakeGamepads) Gamepads() []int {
+	var ids []int
+	for id := range f.states {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (f *fakeGamepads) GamepadName(id int) string {
+	return f.names[id]
+}
+
+func (f *fakeGamepads) GamepadState(id int) (GamepadState, bool) {
+	state, ok := f.states[id]
+	return state, ok
+}
+
+func nearlyEqual(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-5
+}
+
+func TestDeadZones(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		stick, tzone  float32 // dead zones, defaults are used if both zero
+		x, y, trigger float32 // raw values, trigger is in [-1, 1]
+		wantX, wantY  float32
+		wantTrigger   float32
+	}{
+		{name: "rest", trigger: -1},
+		{name: "inside stick dead zone", x: 0.1, y: -0.1, trigger: -1},
+		{name: "full tilt", x: 1, trigger: 1, wantX: 1, wantTrigger: 1},
+		{name: "rescaled", x: 0.575, wantX: 0.5, wantTrigger: (0.5 - 0.05) / 0.95},
+		{name: "diagonal clamped", x: 1, y: 1, trigger: -1, wantX: math.Sqrt2 / 2, wantY: math.Sqrt2 / 2},
+		{name: "inside trigger dead zone", trigger: -0.95},
+		{name: "custom dead zones", stick: 0.5, tzone: 0.5, x: 0.75, wantX: 0.5},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var state State
+			if tt.stick != 0 || tt.tzone != 0 {
+				state.SetDeadZones(tt.stick, tt.tzone)
+			}
+			var raw GamepadState
+			raw.Axes[AxisLeftX] = tt.x
+			raw.Axes[AxisLeftY] = tt.y
+			raw.Axes[AxisLeftTrigger] = tt.trigger
+			raw.Axes[AxisRightTrigger] = -1
+			state.PollGamepads(&fakeGamepads{states: map[int]GamepadState{0: raw}})
+			var x, y = state.GamepadAxis(0, AxisLeftX), state.GamepadAxis(0, AxisLeftY)
+			var trigger = state.GamepadAxis(0, AxisLeftTrigger)
+			if !nearlyEqual(x, tt.wantX) || !nearlyEqual(y, tt.wantY) || !nearlyEqual(trigger, tt.wantTrigger) {
+				t.Fatalf("got (%v, %v, %v), want (%v, %v, %v)", x, y, trigger, tt.wantX, tt.wantY, tt.wantTrigger)
+			}
+		})
+	}
+}
+
+func TestPollGamepadsConnection(t *testing.T) {
+	var source = &fakeGamepads{
+		names:  map[int]string{0: "pad0", 1: "pad1"},
+		states: map[int]GamepadState{},
+	}
+	var state State
+	for _, step := range []struct {
+		name   string
+		ids    []int          // ids of gamepads reported by source
+		want   []int          // want connected gamepads
+		events map[int]string // want events by gamepad id
+	}{
+		{name: "none", want: []int{}},
+		{name: "connect", ids: []int{0, 1}, want: []int{0, 1}, events: map[int]string{0: "connected", 1: "connected"}},
+		{name: "unchanged", ids: []int{0, 1}, want: []int{0, 1}},
+		{name: "disconnect", ids: []int{1}, want: []int{1}, events: map[int]string{0: "disconnected"}},
+		{name: "reconnect", ids: []int{0, 1}, want: []int{0, 1}, events: map[int]string{0: "connected"}},
+	} {
+		source.states = make(map[int]GamepadState)
+		for _, id := range step.ids {
+			source.states[id] = GamepadState{}
+		}
+		var events = make(map[int]string)
+		for _, e := range state.PollGamepads(source) {
+			switch e := e.(type) {
+			case GamepadConnectedEvent:
+				if e.Name != source.names[e.ID] {
+					t.Errorf("%s: name of gamepad %d is %q, want %q", step.name, e.ID, e.Name, source.names[e.ID])
+				}
+				events[e.ID] = "connected"
+			case GamepadDisconnectedEvent:
+				events[e.ID] = "disconnected"
+			default:
+				t.Fatalf("%s: unexpected event %T", step.name, e)
+			}
+		}
+		if step.events == nil {
+			step.events = map[int]string{}
+		}
+		if !reflect.DeepEqual(events, step.events) {
+			t.Fatalf("%s: got events %v, want %v", step.name, events, step.events)
+		}
+		if got := state.Gamepads(); !reflect.DeepEqual(got, step.want) {
+			t.Fatalf("%s: got gamepads %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestGamepadButtonEdges(t *testing.T) {
+	var source = &fakeGamepads{states: map[int]GamepadState{}}
+	var state State
+	for i, step := range []struct {
+		down                      bool
+		wantPressed, wantReleased bool
+	}{
+		{},
+		{down: true, wantPressed: true},
+		{down: true},
+		{down: false, wantReleased: true},
+		{},
+	} {
+		var raw GamepadState
+		raw.Buttons[ButtonA] = step.down
+		source.states[0] = raw
+		state.PollGamepads(source)
+		if got := state.IsGamepadButtonDown(0, ButtonA); got != step.down {
+			t.Errorf("step %d: down = %v, want %v", i, got, step.down)
+		}
+		if got := state.IsGamepadButtonPressed(0, ButtonA); got != step.wantPressed {
+			t.Errorf("step %d: pressed = %v, want %v", i, got, step.wantPressed)
+		}
+		if got := state.IsGamepadButtonReleased(0, ButtonA); got != step.wantReleased {
+			t.Errorf("step %d: released = %v, want %v", i, got, step.wantReleased)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package input
+
+import "github.com/gopherd/three/input/device"
+
+// Key, Action, ModifierKey and MouseButton are defined in the leaf package
+// device shared with window drivers
+type (
+	Key         = device.Key
+	Action      = device.Action
+	ModifierKey = device.ModifierKey
+	MouseButton = device.MouseButton
+)
+
+const (
+	KeyUnknown        = device.KeyUnknown
+	KeySpace          = device.KeySpace
+	KeyApostrophe     = device.KeyApostrophe
+	KeyComma          = device.KeyComma
+	KeyMinus          = device.KeyMinus
+	KeyPeriod         = device.KeyPeriod
+	KeySlash          = device.KeySlash
+	Key0              = device.Key0
+	Key1              = device.Key1
+	Key2              = device.Key2
+	Key3              = device.Key3
+	Key4              = device.Key4
+	Key5              = device.Key5
+	Key6              = device.Key6
+	Key7              = device.Key7
+	Key8              = device.Key8
+	Key9              = device.Key9
+	KeySemicolon      = device.KeySemicolon
+	KeyEqual          = device.KeyEqual
+	KeyA              = device.KeyA
+	KeyB              = device.KeyB
+	KeyC              = device.KeyC
+	KeyD              = device.KeyD
+	KeyE              = device.KeyE
+	KeyF              = device.KeyF
+	KeyG              = device.KeyG
+	KeyH              = device.KeyH
+	KeyI              = device.KeyI
+	KeyJ              = device.KeyJ
+	KeyK              = device.KeyK
+	KeyL              = device.KeyL
+	KeyM              = device.KeyM
+	KeyN              = device.KeyN
+	KeyO              = device.KeyO
+	KeyP              = device.KeyP
+	KeyQ              = device.KeyQ
+	KeyR              = device.KeyR
+	KeyS              = device.KeyS
+	KeyT              = device.KeyT
+	KeyU              = device.KeyU
+	KeyV              = device.KeyV
+	KeyW              = device.KeyW
+	KeyX              = device.KeyX
+	KeyY              = device.KeyY
+	KeyZ              = device.KeyZ
+	KeyLeftBracket    = device.KeyLeftBracket
+	KeyBackslash      = device.KeyBackslash
+	KeyRightBracket   = device.KeyRightBracket
+	KeyGraveAccent    = device.KeyGraveAccent
+	KeyWorld1         = device.KeyWorld1
+	KeyWorld2         = device.KeyWorld2
+	KeyEscape         = device.KeyEscape
+	KeyEnter          = device.KeyEnter
+	KeyTab            = device.KeyTab
+	KeyBackspace      = device.KeyBackspace
+	KeyInsert         = device.KeyInsert
+	KeyDelete         = device.KeyDelete
+	KeyRight          = device.KeyRight
+	KeyLeft           = device.KeyLeft
+	KeyDown           = device.KeyDown
+	KeyUp             = device.KeyUp
+	KeyPageUp         = device.KeyPageUp
+	KeyPageDown       = device.KeyPageDown
+	KeyHome           = device.KeyHome
+	KeyEnd            = device.KeyEnd
+	KeyCapsLock       = device.KeyCapsLock
+	KeyScrollLock     = device.KeyScrollLock
+	KeyNumLock        = device.KeyNumLock
+	KeyPrintScreen    = device.KeyPrintScreen
+	KeyPause          = device.KeyPause
+	KeyF1             = device.KeyF1
+	KeyF2             = device.KeyF2
+	KeyF3             = device.KeyF3
+	KeyF4             = device.KeyF4
+	KeyF5             = device.KeyF5
+	KeyF6             = device.KeyF6
+	KeyF7             = device.KeyF7
+	KeyF8             = device.KeyF8
+	KeyF9             = device.KeyF9
+	KeyF10            = device.KeyF10
+	KeyF11            = device.KeyF11
+	KeyF12            = device.KeyF12
+	KeyF13            = device.KeyF13
+	KeyF14            = device.KeyF14
+	KeyF15            = device.KeyF15
+	KeyF16            = device.KeyF16
+	KeyF17            = device.KeyF17
+	KeyF18            = device.KeyF18
+	KeyF19            = device.KeyF19
+	KeyF20            = device.KeyF20
+	KeyF21            = device.KeyF21
+	KeyF22            = device.KeyF22
+	KeyF23            = device.KeyF23
+	KeyF24            = device.KeyF24
+	KeyF25            = device.KeyF25
+	KeyKP0            = device.KeyKP0
+	KeyKP1            = device.KeyKP1
+	KeyKP2            = device.KeyKP2
+	KeyKP3            = device.KeyKP3
+	KeyKP4            = device.KeyKP4
+	KeyKP5            = device.KeyKP5
+	KeyKP6            = device.KeyKP6
+	KeyKP7            = device.KeyKP7
+	KeyKP8            = device.KeyKP8
+	KeyKP9            = device.KeyKP9
+	KeyKPDecimal      = device.KeyKPDecimal
+	KeyKPDivide       = device.KeyKPDivide
+	KeyKPMultiply     = device.KeyKPMultiply
+	KeyKPSubtract     = device.KeyKPSubtract
+	KeyKPAdd          = device.KeyKPAdd
+	KeyKPEnter        = device.KeyKPEnter
+	KeyKPEqual        = device.KeyKPEqual
+	KeyLeftShift      = device.KeyLeftShift
+	KeyLeftControl    = device.KeyLeftControl
+	KeyLeftAlt        = device.KeyLeftAlt
+	KeyLeftSuper      = device.KeyLeftSuper
+	KeyRightShift     = device.KeyRightShift
+	KeyRightControl   = device.KeyRightControl
+	KeyRightAlt       = device.KeyRightAlt
+	KeyRightSuper     = device.KeyRightSuper
+	KeyMenu           = device.KeyMenu
+	KeyLast           = device.KeyLast
+	Release           = device.Release
+	Press             = device.Press
+	Repeat            = device.Repeat
+	ModShift          = device.ModShift
+	ModControl        = device.ModControl
+	ModAlt            = device.ModAlt
+	ModSuper          = device.ModSuper
+	ModCapsLock       = device.ModCapsLock
+	ModNumLock        = device.ModNumLock
+	MouseButton1      = device.MouseButton1
+	MouseButton2      = device.MouseButton2
+	MouseButton3      = device.MouseButton3
+	MouseButton4      = device.MouseButton4
+	MouseButton5      = device.MouseButton5
+	MouseButton6      = device.MouseButton6
+	MouseButton7      = device.MouseButton7
+	MouseButton8      = device.MouseButton8
+	MouseButtonLast   = device.MouseButtonLast
+	MouseButtonLeft   = device.MouseButtonLeft
+	MouseButtonRight  = device.MouseButtonRight
+	MouseButtonMiddle = device.MouseButtonMiddle
+)
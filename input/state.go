@@ -0,0 +1,141 @@
+package input
+
+import "github.com/gopherd/three/core/event"
+
+type snapshot struct {
+	keys            [KeyLast + 1]bool
+	keysPressed     [KeyLast + 1]bool
+	keysReleased    [KeyLast + 1]bool
+	buttons         [MouseButtonLast + 1]bool
+	buttonsPressed  [MouseButtonLast + 1]bool
+	buttonsReleased [MouseButtonLast + 1]bool
+	mods            ModifierKey
+	x, y            float64
+	dx, dy          float64
+	scrollX         float64
+	scrollY         float64
+	cursorOutside   bool
+}
+
+// State holds polled input state, events are accumulated by Handle and
+// become visible after Update which should be called once per update step
+type State struct {
+	current   snapshot
+	next      snapshot
+	gamepads  map[int]*gamepad
+	deadZones struct {
+		custom  bool
+		stick   float32
+		trigger float32
+	}
+}
+
+// Handle accumulates the input event to state of next frame
+func (state *State) Handle(e event.Event) {
+	var next = &state.next
+	switch e := e.(type) {
+	case KeyEvent:
+		if e.Key < 0 || e.Key > KeyLast {
+			break
+		}
+		switch e.Action {
+		case Press:
+			next.keys[e.Key] = true
+			next.keysPressed[e.Key] = true
+		case Release:
+			next.keys[e.Key] = false
+			next.keysReleased[e.Key] = true
+		}
+		next.mods = e.Mods
+	case MouseButtonEvent:
+		if e.Button < 0 || e.Button > MouseButtonLast {
+			break
+		}
+		switch e.Action {
+		case Press:
+			next.buttons[e.Button] = true
+			next.buttonsPressed[e.Button] = true
+		case Release:
+			next.buttons[e.Button] = false
+			next.buttonsReleased[e.Button] = true
+		}
+		next.mods = e.Mods
+	case MouseMoveEvent:
+		next.x, next.y = e.X, e.Y
+		next.dx += e.DX
+		next.dy += e.DY
+	case ScrollEvent:
+		next.scrollX += e.DX
+		next.scrollY += e.DY
+	case CursorEnterEvent:
+		next.cursorOutside = !e.Entered
+	}
+}
+
+// Update makes accumulated events visible and starts accumulating events
+// of next frame
+func (state *State) Update() {
+	state.current = state.next
+	var next = &state.next
+	next.keysPressed = [KeyLast + 1]bool{}
+	next.keysReleased = [KeyLast + 1]bool{}
+	next.buttonsPressed = [MouseButtonLast + 1]bool{}
+	next.buttonsReleased = [MouseButtonLast + 1]bool{}
+	next.dx, next.dy = 0, 0
+	next.scrollX, next.scrollY = 0, 0
+}
+
+// IsKeyDown reports whether the key is held down
+func (state *State) IsKeyDown(key Key) bool {
+	return key >= 0 && key <= KeyLast && state.current.keys[key]
+}
+
+// IsKeyPressed reports whether the key was pressed since the previous update
+func (state *State) IsKeyPressed(key Key) bool {
+	return key >= 0 && key <= KeyLast && state.current.keysPressed[key]
+}
+
+// IsKeyReleased reports whether the key was released since the previous update
+func (state *State) IsKeyReleased(key Key) bool {
+	return key >= 0 && key <= KeyLast && state.current.keysReleased[key]
+}
+
+// IsMouseButtonDown reports whether the mouse button is held down
+func (state *State) IsMouseButtonDown(button MouseButton) bool {
+	return button >= 0 && button <= MouseButtonLast && state.current.buttons[button]
+}
+
+// IsMouseButtonPressed reports whether the mouse button was pressed since the previous update
+func (state *State) IsMouseButtonPressed(button MouseButton) bool {
+	return button >= 0 && button <= MouseButtonLast && state.current.buttonsPressed[button]
+}
+
+// IsMouseButtonReleased reports whether the mouse button was released since the previous update
+func (state *State) IsMouseButtonReleased(button MouseButton) bool {
+	return button >= 0 && button <= MouseButtonLast && state.current.buttonsReleased[button]
+}
+
+// Mods returns modifier keys of the latest key or mouse button event
+func (state *State) Mods() ModifierKey {
+	return state.current.mods
+}
+
+// MousePosition returns cursor position in screen coordinates
+func (state *State) MousePosition() (x, y float64) {
+	return state.current.x, state.current.y
+}
+
+// MouseDelta returns cursor movement since the previous update
+func (state *State) MouseDelta() (dx, dy float64) {
+	return state.current.dx, state.current.dy
+}
+
+// ScrollDelta returns scroll offset since the previous update
+func (state *State) ScrollDelta() (dx, dy float64) {
+	return state.current.scrollX, state.current.scrollY
+}
+
+// CursorInside reports whether the cursor is inside the window
+func (state *State) CursorInside() bool {
+	return !state.current.cursorOutside
+}
@@ -0,0 +1,121 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/gopherd/three/core/event"
+)
+
+func TestStateKeys(t *testing.T) {
+	var state State
+	for i, step := range []struct {
+		events                             []event.Event
+		wantDown, wantPressed, wantRelease bool
+	}{
+		{},
+		{events: []event.Event{KeyEvent{Key: KeyA, Action: Press}}, wantDown: true, wantPressed: true},
+		{events: []event.Event{KeyEvent{Key: KeyA, Action: Repeat}}, wantDown: true},
+		{wantDown: true},
+		{events: []event.Event{KeyEvent{Key: KeyA, Action: Release}}, wantRelease: true},
+		{},
+		// pressed and released between two updates
+		{events: []event.Event{KeyEvent{Key: KeyA, Action: Press}, KeyEvent{Key: KeyA, Action: Release}}, wantPressed: true, wantRelease: true},
+		// keys out of range are ignored
+		{events: []event.Event{KeyEvent{Key: KeyUnknown, Action: Press}, KeyEvent{Key: KeyLast + 1, Action: Press}}},
+	} {
+		for _, e := range step.events {
+			state.Handle(e)
+		}
+		state.Update()
+		if got := state.IsKeyDown(KeyA); got != step.wantDown {
+			t.Errorf("step %d: down = %v, want %v", i, got, step.wantDown)
+		}
+		if got := state.IsKeyPressed(KeyA); got != step.wantPressed {
+			t.Errorf("step %d: pressed = %v, want %v", i, got, step.wantPressed)
+		}
+		if got := state.IsKeyReleased(KeyA); got != step.wantRelease {
+			t.Errorf("step %d: released = %v, want %v", i, got, step.wantRelease)
+		}
+	}
+}
+
+func TestStateInvisibleUntilUpdate(t *testing.T) {
+	var state State
+	state.Handle(KeyEvent{Key: KeyA, Action: Press})
+	state.Handle(MouseButtonEvent{Button: MouseButtonLeft, Action: Press})
+	state.Handle(MouseMoveEvent{X: 1, Y: 2, DX: 1, DY: 2})
+	if state.IsKeyDown(KeyA) || state.IsMouseButtonDown(MouseButtonLeft) {
+		t.Fatal("events are visible before Update")
+	}
+	if x, y := state.MousePosition(); x != 0 || y != 0 {
+		t.Fatalf("mouse position is (%v, %v) before Update", x, y)
+	}
+}
+
+func TestStateMouse(t *testing.T) {
+	var state State
+	for i, step := range []struct {
+		events          []event.Event
+		wantDown        bool
+		wantPressed     bool
+		wantX, wantY    float64
+		wantDX, wantDY  float64
+		wantScroll      float64
+		wantInside      bool
+		wantMods        ModifierKey
+		wantReleaseLeft bool
+	}{
+		{wantInside: true},
+		{
+			events: []event.Event{
+				MouseButtonEvent{Button: MouseButtonLeft, Action: Press, Mods: ModShift},
+				MouseMoveEvent{X: 10, Y: 20, DX: 10, DY: 20},
+				MouseMoveEvent{X: 15, Y: 18, DX: 5, DY: -2},
+				ScrollEvent{DY: 1},
+				ScrollEvent{DY: 2},
+			},
+			wantDown: true, wantPressed: true,
+			wantX: 15, wantY: 18, wantDX: 15, wantDY: 18,
+			wantScroll: 3, wantInside: true, wantMods: ModShift,
+		},
+		// position is kept while deltas are reset
+		{wantDown: true, wantX: 15, wantY: 18, wantInside: true, wantMods: ModShift},
+		{
+			events: []event.Event{
+				MouseButtonEvent{Button: MouseButtonLeft, Action: Release},
+				CursorEnterEvent{Entered: false},
+			},
+			wantX: 15, wantY: 18, wantReleaseLeft: true,
+		},
+		{events: []event.Event{CursorEnterEvent{Entered: true}}, wantX: 15, wantY: 18, wantInside: true},
+	} {
+		for _, e := range step.events {
+			state.Handle(e)
+		}
+		state.Update()
+		if got := state.IsMouseButtonDown(MouseButtonLeft); got != step.wantDown {
+			t.Errorf("step %d: down = %v, want %v", i, got, step.wantDown)
+		}
+		if got := state.IsMouseButtonPressed(MouseButtonLeft); got != step.wantPressed {
+			t.Errorf("step %d: pressed = %v, want %v", i, got, step.wantPressed)
+		}
+		if got := state.IsMouseButtonReleased(MouseButtonLeft); got != step.wantReleaseLeft {
+			t.Errorf("step %d: released = %v, want %v", i, got, step.wantReleaseLeft)
+		}
+		if x, y := state.MousePosition(); x != step.wantX || y != step.wantY {
+			t.Errorf("step %d: position = (%v, %v), want (%v, %v)", i, x, y, step.wantX, step.wantY)
+		}
+		if dx, dy := state.MouseDelta(); dx != step.wantDX || dy != step.wantDY {
+			t.Errorf("step %d: delta = (%v, %v), want (%v, %v)", i, dx, dy, step.wantDX, step.wantDY)
+		}
+		if _, dy := state.ScrollDelta(); dy != step.wantScroll {
+			t.Errorf("step %d: scroll = %v, want %v", i, dy, step.wantScroll)
+		}
+		if got := state.CursorInside(); got != step.wantInside {
+			t.Errorf("step %d: inside = %v, want %v", i, got, step.wantInside)
+		}
+		if got := state.Mods(); got != step.wantMods {
+			t.Errorf("step %d: mods = %v, want %v", i, got, step.wantMods)
+		}
+	}
+}
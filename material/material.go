@@ -1,6 +1,9 @@
 package material
 
-import "github.com/gopherd/three/driver/renderer/shader"
+import (
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/driver/renderer/shader"
+)
 
 type FaceSide int
 
@@ -10,11 +13,102 @@ const (
 	DoubleSide
 )
 
+// Blending specifies how a material is blended with the framebuffer
+type Blending int
+
+const (
+	NormalBlending Blending = iota
+	NoBlending
+	AdditiveBlending
+	SubtractiveBlending
+	MultiplyBlending
+	CustomBlending
+)
+
 type Options struct {
 	Side         FaceSide
 	Transparent  bool
 	Opacity      float32
 	VertexColors bool
+
+	// Blending is used by transparent materials, NormalBlending is ignored
+	// for opaque materials. BlendXXX fields are used only by CustomBlending,
+	// BlendXXXAlpha fields fallback to non-alpha fields if all of them are zero
+	Blending           Blending
+	BlendEquation      renderer.BlendEquation
+	BlendSrc           renderer.BlendFactor
+	BlendDst           renderer.BlendFactor
+	BlendEquationAlpha renderer.BlendEquation
+	BlendSrcAlpha      renderer.BlendFactor
+	BlendDstAlpha      renderer.BlendFactor
+
+	DepthFunc         renderer.DepthFunc
+	DisableDepthTest  bool
+	DisableDepthWrite bool
+}
+
+// RenderState returns renderer state required by the options
+func (options Options) RenderState() renderer.State {
+	var state = renderer.State{
+		Depth: renderer.DepthState{
+			Test:  !options.DisableDepthTest,
+			Write: !options.DisableDepthWrite,
+			Func:  options.DepthFunc,
+		},
+	}
+	switch options.Side {
+	case BackSide:
+		state.CullFace = renderer.CullFront
+	case DoubleSide:
+		state.CullFace = renderer.CullNone
+	default:
+		state.CullFace = renderer.CullBack
+	}
+	if options.Blending == NoBlending || (options.Blending == NormalBlending && !options.Transparent) {
+		return state
+	}
+	var blend = &state.Blend
+	blend.Enabled = true
+	switch options.Blending {
+	case NormalBlending:
+		blend.Src, blend.Dst = renderer.BlendSrcAlpha, renderer.BlendOneMinusSrcAlpha
+		blend.SrcAlpha, blend.DstAlpha = renderer.BlendOne, renderer.BlendOneMinusSrcAlpha
+	case AdditiveBlending:
+		blend.Src, blend.Dst = renderer.BlendSrcAlpha, renderer.BlendOne
+		blend.SrcAlpha, blend.DstAlpha = renderer.BlendOne, renderer.BlendOne
+	case SubtractiveBlending:
+		blend.Src, blend.Dst = renderer.BlendZero, renderer.BlendOneMinusSrcColor
+		blend.SrcAlpha, blend.DstAlpha = renderer.BlendZero, renderer.BlendOne
+	case MultiplyBlending:
+		blend.Src, blend.Dst = renderer.BlendZero, renderer.BlendSrcColor
+		blend.SrcAlpha, blend.DstAlpha = renderer.BlendZero, renderer.BlendSrcAlpha
+	case CustomBlending:
+		blend.Equation = options.BlendEquation
+		blend.Src, blend.Dst = options.BlendSrc, options.BlendDst
+		if options.BlendEquationAlpha == 0 && options.BlendSrcAlpha == 0 && options.BlendDstAlpha == 0 {
+			blend.EquationAlpha = options.BlendEquation
+			blend.SrcAlpha, blend.DstAlpha = options.BlendSrc, options.BlendDst
+		} else {
+			blend.EquationAlpha = options.BlendEquationAlpha
+			blend.SrcAlpha, blend.DstAlpha = options.BlendSrcAlpha, options.BlendDstAlpha
+		}
+	}
+	return state
+}
+
+// Defines returns shader defines derived from the options
+func (options Options) Defines() shader.Defines {
+	var defines = make(shader.Defines)
+	if options.VertexColors {
+		defines["USE_VERTEX_COLORS"] = ""
+	}
+	switch options.Side {
+	case BackSide:
+		defines["FLIP_SIDED"] = ""
+	case DoubleSide:
+		defines["DOUBLE_SIDED"] = ""
+	}
+	return defines
 }
 
 type Material interface {
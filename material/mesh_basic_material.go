@@ -1,9 +1,50 @@
 package material
 
-import "github.com/gopherd/three/driver/renderer/shader"
+import (
+	"image/color"
+
+	"github.com/gopherd/doge/operator"
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer/shader"
+)
+
+const meshBasicVertex = `#include <common>
+#include <color_pars_vertex>
+#include <fog_pars_vertex>
+#include <skinning_pars_vertex>
+in vec3 position;
+uniform mat4 proj;
+uniform mat4 view;
+uniform mat4 transform;
+void main() {
+	vec3 transformed = position;
+#include <color_vertex>
+#include <skinning_vertex>
+	vec4 mvPosition = view * transform * vec4(transformed, 1.0);
+	gl_Position = proj * mvPosition;
+#include <fog_vertex>
+}
+`
+
+const meshBasicFragment = `#include <common>
+#include <colorspace_pars>
+#include <color_pars_fragment>
+#include <fog_pars_fragment>
+uniform vec3 diffuse;
+uniform float opacity;
+out vec4 fragColor;
+void main() {
+	vec4 diffuseColor = vec4(diffuse, opacity);
+#include <color_fragment>
+	fragColor = diffuseColor;
+#include <colorspace_fragment>
+#include <fog_fragment>
+}
+`
 
 type MeshBasicMaterialParameters struct {
 	Options Options
+	Color   color.Color // Color of material, default white
 }
 
 type MeshBasicMaterial struct {
@@ -16,9 +57,14 @@ func NewMeshBasicMaterial(paramters MeshBasicMaterialParameters) *MeshBasicMater
 	m := &MeshBasicMaterial{
 		parameters: paramters,
 	}
+	m.shader.Vertex = meshBasicVertex
+	m.shader.Fragment = meshBasicFragment
+	m.shader.Uniforms = make(map[string]shader.Uniform)
 	return m
 }
 
+// Parameters returns parameters of material, SetNeedsUpdate(true) must be
+// called after parameters modified
 func (m *MeshBasicMaterial) Parameters() *MeshBasicMaterialParameters {
 	return &m.parameters
 }
@@ -27,6 +73,20 @@ func (m *MeshBasicMaterial) Options() Options {
 	return m.parameters.Options
 }
 
+// Shader implements Material Shader method, uniforms and defines are
+// rebuilt only if the material needs update
 func (m *MeshBasicMaterial) Shader() shader.Shader {
+	if !m.NeedsUpdate() {
+		return m.shader
+	}
+	m.SetNeedsUpdate(false)
+	var diffuse = core.Vec3(1, 1, 1)
+	if m.parameters.Color != nil {
+		var c = core.Color(m.parameters.Color)
+		diffuse = core.Vec3(c.X(), c.Y(), c.Z())
+	}
+	m.shader.Uniforms["diffuse"] = diffuse
+	m.shader.Uniforms["opacity"] = operator.If(m.parameters.Options.Transparent, m.parameters.Options.Opacity, 1)
+	m.shader.SetDefines(m.parameters.Options.Defines())
 	return m.shader
 }
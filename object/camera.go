@@ -19,10 +19,25 @@ type Camera interface {
 
 	CameraType() CameraType
 	Projection() core.Matrix4
+	// View returns view matrix, i.e. inverse of transform in world space
+	View() core.Matrix4
 	SetViewOffset(fullWidth, fullHeight, x, y, width, height core.Float)
+	ClearViewOffset()
+	// SetAspect sets width to height ratio of the camera, orthographic
+	// cameras keep their vertical extent and center
+	SetAspect(aspect core.Float)
 
+	// IntersectsBox reports whether the box in world space intersects frustum
 	IntersectsBox(box geometry.Box3) bool
+	// ContainsPoint reports whether the point in world space is in frustum
 	ContainsPoint(pos core.Vector3) bool
+
+	// LayerMask returns layers the camera sees, objects are rendered and
+	// picked by the camera only if they're in the mask
+	LayerMask() Layers
+	// SetLayerMask sets layers the camera sees, it's DefaultLayers by
+	// default, e.g. gizmos in another layer are seen by editor cameras only
+	SetLayerMask(mask Layers)
 }
 
 type cameraImpl struct {
@@ -40,8 +55,30 @@ type cameraImpl struct {
 		}
 		notNeedsUpdate bool
 	}
+	// world holds frustum in world space and the matrix it's computed from
+	world struct {
+		frustum  geometry.Frustum
+		projView core.Matrix4
+	}
 	zoom      core.Float
 	near, far core.Float
+	layerMask Layers
+}
+
+// Init initializes the camera, it sees DefaultLayers
+func (camera *cameraImpl) Init() {
+	camera.object3d.Init()
+	camera.layerMask = DefaultLayers
+}
+
+// LayerMask implements Camera LayerMask method
+func (camera *cameraImpl) LayerMask() Layers {
+	return camera.layerMask
+}
+
+// SetLayerMask implements Camera SetLayerMask method
+func (camera *cameraImpl) SetLayerMask(mask Layers) {
+	camera.layerMask = mask
 }
 
 // TODO(delay) Bounds implements Object Bounds method
@@ -65,14 +102,69 @@ func (camera *cameraImpl) SetViewOffset(fullWidth, fullHeight, x, y, width, heig
 	camera.setProjectionNeedsUpdate(true)
 }
 
+// ClearViewOffset implements Camera ClearViewOffset method
+func (camera *cameraImpl) ClearViewOffset() {
+	camera.proj.view.enabled = false
+	camera.setProjectionNeedsUpdate(true)
+}
+
+func (camera *cameraImpl) GetZoom() core.Float {
+	return camera.zoom
+}
+
+func (camera *cameraImpl) SetZoom(zoom core.Float) {
+	camera.zoom = zoom
+	camera.setProjectionNeedsUpdate(true)
+}
+
+func (camera *cameraImpl) GetNear() core.Float {
+	return camera.near
+}
+
+func (camera *cameraImpl) SetNear(near core.Float) {
+	camera.near = near
+	camera.setProjectionNeedsUpdate(true)
+}
+
+func (camera *cameraImpl) GetFar() core.Float {
+	return camera.far
+}
+
+func (camera *cameraImpl) SetFar(far core.Float) {
+	camera.far = far
+	camera.setProjectionNeedsUpdate(true)
+}
+
+// View implements Camera View method
+func (camera *cameraImpl) View() core.Matrix4 {
+	return camera.TransformWorld().Invert()
+}
+
+// LookAt implements Object LookAt method, the -Z axis of camera points to
+// the position
+func (camera *cameraImpl) LookAt(pos core.Vector3) {
+	camera.lookAt(camera.TransformWorld().GetPosition(), pos)
+}
+
 // IntersectsBox implements Camera IntersectsBox method
 func (camera *cameraImpl) IntersectsBox(box geometry.Box3) bool {
-	return camera.proj.frustum.IntersectsBox(box)
+	return camera.worldFrustum().IntersectsBox(box)
 }
 
 // ContainsPoint implements Camera ContainsPoint method
 func (camera *cameraImpl) ContainsPoint(point core.Vector3) bool {
-	return camera.proj.frustum.ContainsPoint(point)
+	return camera.worldFrustum().ContainsPoint(point)
+}
+
+// worldFrustum returns frustum in world space, it's recomputed only if
+// projection or view changed
+func (camera *cameraImpl) worldFrustum() *geometry.Frustum {
+	var projView = camera.proj.matrix.Dot(camera.View())
+	if projView != camera.world.projView {
+		camera.world.projView = projView
+		camera.world.frustum.SetFromProjectionMatrix(projView)
+	}
+	return &camera.world.frustum
 }
 
 func (camera *cameraImpl) isProjectionNeedsUpdate() bool {
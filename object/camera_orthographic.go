@@ -72,3 +72,57 @@ func (camera *OrthographicCamera) updateProjectionMatrix() {
 	camera.proj.matrix.MakeOrthographic(left, right, top, bottom, camera.near, camera.far)
 	camera.projectionMatrixChanged()
 }
+
+func (camera *OrthographicCamera) GetLeft() core.Float {
+	return camera.left
+}
+
+func (camera *OrthographicCamera) SetLeft(left core.Float) {
+	camera.left = left
+	camera.setProjectionNeedsUpdate(true)
+}
+
+func (camera *OrthographicCamera) GetRight() core.Float {
+	return camera.right
+}
+
+func (camera *OrthographicCamera) SetRight(right core.Float) {
+	camera.right = right
+	camera.setProjectionNeedsUpdate(true)
+}
+
+func (camera *OrthographicCamera) GetTop() core.Float {
+	return camera.top
+}
+
+func (camera *OrthographicCamera) SetTop(top core.Float) {
+	camera.top = top
+	camera.setProjectionNeedsUpdate(true)
+}
+
+func (camera *OrthographicCamera) GetBottom() core.Float {
+	return camera.bottom
+}
+
+func (camera *OrthographicCamera) SetBottom(bottom core.Float) {
+	camera.bottom = bottom
+	camera.setProjectionNeedsUpdate(true)
+}
+
+// SetBounds sets left, right, top and bottom planes of the camera
+func (camera *OrthographicCamera) SetBounds(left, right, top, bottom core.Float) {
+	camera.left = left
+	camera.right = right
+	camera.top = top
+	camera.bottom = bottom
+	camera.setProjectionNeedsUpdate(true)
+}
+
+// SetAspect implements Camera SetAspect method
+func (camera *OrthographicCamera) SetAspect(aspect core.Float) {
+	var cx = (camera.right + camera.left) / 2
+	var dx = (camera.top - camera.bottom) * aspect / 2
+	camera.left = cx - dx
+	camera.right = cx + dx
+	camera.setProjectionNeedsUpdate(true)
+}
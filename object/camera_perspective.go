@@ -77,6 +77,26 @@ func (camera *PerspectiveCamera) updateProjectionMatrix() {
 	camera.projectionMatrixChanged()
 }
 
+func (camera *PerspectiveCamera) GetFov() core.Float {
+	return camera.fov
+}
+
+// SetFov sets vertical field of view in degrees
+func (camera *PerspectiveCamera) SetFov(fov core.Float) {
+	camera.fov = fov
+	camera.setProjectionNeedsUpdate(true)
+}
+
+func (camera *PerspectiveCamera) GetAspect() core.Float {
+	return camera.aspect
+}
+
+// SetAspect implements Camera SetAspect method
+func (camera *PerspectiveCamera) SetAspect(aspect core.Float) {
+	camera.aspect = aspect
+	camera.setProjectionNeedsUpdate(true)
+}
+
 // see {@link http://www.bobatkins.com/photography/technical/field_of_view.html}
 func (camera *PerspectiveCamera) SetFocalLength(focalLength core.Float) {
 	var vExtentSlope = 0.5 * camera.GetFilmHeight() / focalLength
@@ -5,19 +5,25 @@ import "github.com/gopherd/three/core/event"
 type (
 	AddedEvent   struct{}
 	RemovedEvent struct{}
+	// DetachedEvent is dispatched to an object and all its descendants when
+	// the object is removed from the tree, but not moved to another parent
+	DetachedEvent struct{}
 )
 
 //@mod:final
 var (
-	AddedEventType   = event.TypeOf[*AddedEvent](nil)
-	RemovedEventType = event.TypeOf[*RemovedEvent](nil)
+	AddedEventType    = event.TypeOf[*AddedEvent](nil)
+	RemovedEventType  = event.TypeOf[*RemovedEvent](nil)
+	DetachedEventType = event.TypeOf[*DetachedEvent](nil)
 )
 
-func (AddedEvent) Type() event.Type   { return AddedEventType }
-func (RemovedEvent) Type() event.Type { return RemovedEventType }
+func (AddedEvent) Type() event.Type    { return AddedEventType }
+func (RemovedEvent) Type() event.Type  { return RemovedEventType }
+func (DetachedEvent) Type() event.Type { return DetachedEventType }
 
 //@mod:final
 var (
-	addedEvent   = AddedEvent{}
-	removedEvent = RemovedEvent{}
+	addedEvent    = AddedEvent{}
+	removedEvent  = RemovedEvent{}
+	detachedEvent = DetachedEvent{}
 )
@@ -0,0 +1,92 @@
+package object
+
+import (
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/geometry"
+)
+
+var (
+	_ Object = (*Object3D)(nil)
+	_ Object = (*Group)(nil)
+)
+
+// Object3D is the base implementation of Object which draws nothing, user
+// types embed it to implement Object and call Init before use:
+//
+//	type Player struct {
+//		object.Object3D
+//	}
+//
+//	func NewPlayer() *Player {
+//		var player = new(Player)
+//		player.Init()
+//		return player
+//	}
+//
+// Types drawing something override Bounds and Render, and implement
+//
+//	Material() material.Material
+//
+// since only objects with a material are collected to render.
+type Object3D struct {
+	object3d
+}
+
+// NewObject3D creates an empty Object3D
+func NewObject3D() *Object3D {
+	var obj = new(Object3D)
+	obj.Init()
+	return obj
+}
+
+// Bounds implements Object Bounds method, it's the union of bounds of
+// visible children transformed to local space of the object, so the box
+// transformed by TransformWorld bounds them in world space. Bounds of
+// nested containers include their children, while bounds of objects
+// drawing something, e.g. Mesh, don't.
+func (obj *Object3D) Bounds() geometry.Box3 {
+	return childrenBounds(obj)
+}
+
+// Render implements Object Render method, nothing is drawn
+func (obj *Object3D) Render(renderer renderer.Renderer, proj, view, transform core.Matrix4) {}
+
+// childrenBounds returns the union of bounds of visible children of the
+// object in its local space, empty if there are none
+func childrenBounds(object Object) geometry.Box3 {
+	var bounds = geometry.EmptyBox3()
+	for i, n := 0, object.NumChild(); i < n; i++ {
+		var child = object.GetChildByIndex(i)
+		if !child.Visible() {
+			continue
+		}
+		if box := child.Bounds().ApplyMatrix4(child.Transform()); !box.IsEmpty() {
+			bounds = bounds.Union(box)
+		}
+	}
+	return bounds
+}
+
+// Group is an object to organize hierarchies without geometry, e.g. to
+// move or hide a set of objects together
+type Group struct {
+	Object3D
+}
+
+// NewGroup creates an empty Group
+func NewGroup() *Group {
+	var group = new(Group)
+	group.Init()
+	return group
+}
+
+// Type implements Object Type method
+func (group *Group) Type() string { return "Group" }
+
+// Add attaches children to the group
+func (group *Group) Add(children ...Object) {
+	for _, child := range children {
+		Attatch(group, child)
+	}
+}
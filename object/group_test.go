@@ -0,0 +1,125 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/geometry"
+	"github.com/gopherd/three/material"
+)
+
+// newUnitMesh creates a mesh whose bounds is the unit box at origin
+func newUnitMesh(x, y, z core.Float) *Mesh {
+	var g = geometry.NewBufferGeometry()
+	var positions = geometry.NewFloat32Attribute(2, 3)
+	positions.SetXYZ(0, 0, 0, 0)
+	positions.SetXYZ(1, 1, 1, 1)
+	g.SetAttribute(geometry.AttributePosition, positions)
+	g.ComputeBounds()
+	var mesh = NewMesh(g, material.NewMeshBasicMaterial(material.MeshBasicMaterialParameters{}))
+	mesh.SetPosition(core.Vec3(x, y, z))
+	return mesh
+}
+
+func TestGroupBounds(t *testing.T) {
+	var box = func(x0, y0, z0, x1, y1, z1 core.Float) geometry.Box3 {
+		return geometry.Box3{Min: core.Vec3(x0, y0, z0), Max: core.Vec3(x1, y1, z1)}
+	}
+	for _, tt := range []struct {
+		name  string
+		build func() *Group
+		want  geometry.Box3
+	}{
+		{
+			name:  "empty",
+			build: NewGroup,
+			want:  geometry.EmptyBox3(),
+		},
+		{
+			name: "children",
+			build: func() *Group {
+				var group = NewGroup()
+				group.Add(newUnitMesh(0, 0, 0), newUnitMesh(2, -3, 0))
+				return group
+			},
+			want: box(0, -3, 0, 3, 1, 1),
+		},
+		{
+			name: "invisible child",
+			build: func() *Group {
+				var group = NewGroup()
+				var hidden = newUnitMesh(5, 5, 5)
+				hidden.SetVisible(false)
+				group.Add(newUnitMesh(0, 0, 0), hidden)
+				return group
+			},
+			want: box(0, 0, 0, 1, 1, 1),
+		},
+		{
+			name: "nested group",
+			build: func() *Group {
+				var inner = NewGroup()
+				inner.Add(newUnitMesh(1, 0, 0))
+				inner.SetPosition(core.Vec3(0, 0, 2))
+				var group = NewGroup()
+				group.Add(inner)
+				return group
+			},
+			want: box(1, 0, 2, 2, 1, 3),
+		},
+		{
+			name: "children of mesh excluded",
+			build: func() *Group {
+				var mesh = newUnitMesh(0, 0, 0)
+				Attatch(mesh, newUnitMesh(5, 5, 5))
+				var group = NewGroup()
+				group.Add(mesh)
+				return group
+			},
+			want: box(0, 0, 0, 1, 1, 1),
+		},
+		{
+			name: "scaled child",
+			build: func() *Group {
+				var mesh = newUnitMesh(0, 0, 0)
+				mesh.SetScale(core.Vec3(-2, 1, 1))
+				var group = NewGroup()
+				group.Add(mesh)
+				return group
+			},
+			want: box(-2, 0, 0, 0, 1, 1),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.build().Bounds(); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupNotCollected(t *testing.T) {
+	var camera = NewPerspectiveCamera(45, 1, 0.1, 100)
+	camera.SetPosition(core.Vec3(0, 0, 10))
+	camera.LookAt(core.Vec3(0, 0, 0))
+	var inner, outer = NewGroup(), NewGroup()
+	inner.Add(newUnitMesh(0, 0, 0))
+	outer.Add(inner, newUnitMesh(1000, 0, 0))
+	// user types embedding Object3D are containers as well
+	var player = &struct{ Object3D }{}
+	player.Init()
+	Attatch(player, newUnitMesh(0, 1, 0))
+	var scene = new(BasicScene)
+	scene.Add(outer)
+	scene.Add(player)
+
+	var r = renderer.NullRenderer()
+	if err := r.Init(100, 100); err != nil {
+		t.Fatal(err)
+	}
+	scene.Render(r, camera)
+	if stats := scene.RenderStats(); stats.Drawn != 2 || stats.Culled != 1 {
+		t.Fatalf("got %d drawn and %d culled, want 2 drawn and 1 culled", stats.Drawn, stats.Culled)
+	}
+}
@@ -0,0 +1,39 @@
+package object
+
+// Layers is a mask of 32 layers, an object is rendered by a pass only if
+// their layers intersect. Objects are in layer 0 by default.
+type Layers uint32
+
+const (
+	DefaultLayers Layers = 1          // DefaultLayers contains layer 0 only
+	AllLayers     Layers = 0xFFFFFFFF // AllLayers contains all layers
+)
+
+// LayerMask returns the mask of layers, layer is in range [0, 32)
+func LayerMask(layers ...int) Layers {
+	var mask Layers
+	for _, layer := range layers {
+		mask |= 1 << uint(layer)
+	}
+	return mask
+}
+
+// Enable returns layers with the layer enabled
+func (layers Layers) Enable(layer int) Layers {
+	return layers | 1<<uint(layer)
+}
+
+// Disable returns layers with the layer disabled
+func (layers Layers) Disable(layer int) Layers {
+	return layers &^ (1 << uint(layer))
+}
+
+// IsEnabled reports whether the layer is enabled
+func (layers Layers) IsEnabled(layer int) bool {
+	return layers&(1<<uint(layer)) != 0
+}
+
+// Test reports whether layers intersect other
+func (layers Layers) Test(other Layers) bool {
+	return layers&other != 0
+}
@@ -1 +1,159 @@
 package object
+
+import (
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/geometry"
+)
+
+// Max number of lights of each kind in the uniform block `Lights', lights
+// beyond the limits are ignored. They must match MAX_DIR_LIGHTS and
+// MAX_POINT_LIGHTS of chunk lights_pars
+const (
+	MaxDirectionalLights = 4
+	MaxPointLights       = 4
+)
+
+// DirectionalLightUniform is the element of directionalLights in the
+// uniform block `Lights', direction points to the light in view space
+type DirectionalLightUniform struct {
+	Direction core.Vector3
+	Color     core.Vector3
+}
+
+// PointLightUniform is the element of pointLights in the uniform block
+// `Lights', position is in view space
+type PointLightUniform struct {
+	Position core.Vector3
+	Color    core.Vector3
+	Distance core.Float
+	Decay    core.Float
+}
+
+// LightsBlock is the uniform block `Lights' shared by all programs, it's
+// uploaded once per frame from lights of the scene
+type LightsBlock struct {
+	AmbientColor   core.Vector3
+	NumDirectional int32
+	NumPoint       int32
+	Directional    [MaxDirectionalLights]DirectionalLightUniform
+	Point          [MaxPointLights]PointLightUniform
+}
+
+// light is implemented by light objects, lights are collected instead of
+// rendered
+type light interface {
+	Object
+	// addTo adds the light with transform in world space to block
+	addTo(block *LightsBlock, view, transform core.Matrix4)
+}
+
+type lightImpl struct {
+	object3d
+	color     core.Vector3
+	intensity core.Float
+}
+
+func (l *lightImpl) init(color core.Vector3, intensity core.Float) {
+	l.color = color
+	l.intensity = intensity
+	l.Init()
+}
+
+// Bounds implements Object Bounds method, lights have no bounds
+func (l *lightImpl) Bounds() geometry.Box3 {
+	return geometry.EmptyBox3()
+}
+
+// Color returns color of light
+func (l *lightImpl) Color() core.Vector3 {
+	return l.color
+}
+
+// SetColor sets color of light
+func (l *lightImpl) SetColor(color core.Vector3) {
+	l.color = color
+}
+
+// Intensity returns intensity of light
+func (l *lightImpl) Intensity() core.Float {
+	return l.intensity
+}
+
+// SetIntensity sets intensity of light
+func (l *lightImpl) SetIntensity(intensity core.Float) {
+	l.intensity = intensity
+}
+
+var _ light = (*AmbientLight)(nil)
+
+// AmbientLight lights all objects equally
+type AmbientLight struct {
+	lightImpl
+}
+
+func NewAmbientLight(color core.Vector3, intensity core.Float) *AmbientLight {
+	var l = new(AmbientLight)
+	l.init(color, intensity)
+	return l
+}
+
+func (l *AmbientLight) addTo(block *LightsBlock, view, transform core.Matrix4) {
+	block.AmbientColor = block.AmbientColor.Add(l.color.Mul(l.intensity))
+}
+
+var _ light = (*DirectionalLight)(nil)
+
+// DirectionalLight emits parallel light from its position towards the
+// world origin, like sunlight
+type DirectionalLight struct {
+	lightImpl
+}
+
+func NewDirectionalLight(color core.Vector3, intensity core.Float) *DirectionalLight {
+	var l = new(DirectionalLight)
+	l.init(color, intensity)
+	return l
+}
+
+func (l *DirectionalLight) addTo(block *LightsBlock, view, transform core.Matrix4) {
+	if block.NumDirectional >= MaxDirectionalLights {
+		return
+	}
+	var direction = view.DotVec3(transform.GetPosition()).Sub(view.DotVec3(core.Vector3{}))
+	block.Directional[block.NumDirectional] = DirectionalLightUniform{
+		Direction: direction.Normalize(),
+		Color:     l.color.Mul(l.intensity),
+	}
+	block.NumDirectional++
+}
+
+var _ light = (*PointLight)(nil)
+
+// PointLight emits light from its position in all directions, light fades
+// out to zero at distance if distance is greater than 0
+type PointLight struct {
+	lightImpl
+	distance core.Float
+	decay    core.Float
+}
+
+func NewPointLight(color core.Vector3, intensity, distance, decay core.Float) *PointLight {
+	var l = new(PointLight)
+	l.distance = distance
+	l.decay = decay
+	l.init(color, intensity)
+	return l
+}
+
+func (l *PointLight) addTo(block *LightsBlock, view, transform core.Matrix4) {
+	if block.NumPoint >= MaxPointLights {
+		return
+	}
+	block.Point[block.NumPoint] = PointLightUniform{
+		Position: view.DotVec3(transform.GetPosition()),
+		Color:    l.color.Mul(l.intensity),
+		Distance: l.distance,
+		Decay:    l.decay,
+	}
+	block.NumPoint++
+}
@@ -0,0 +1,51 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer"
+)
+
+func TestSceneLights(t *testing.T) {
+	var camera = NewPerspectiveCamera(45, 1, 0.1, 100)
+	camera.SetPosition(core.Vec3(0, 0, 10))
+	camera.LookAt(core.Vec3(0, 0, 0))
+	var sun = NewDirectionalLight(core.Vec3(1, 1, 1), 0.5)
+	sun.SetPosition(core.Vec3(0, 5, 0))
+	var lamp = NewPointLight(core.Vec3(1, 0, 0), 2, 10, 1)
+	lamp.SetPosition(core.Vec3(0, 0, 5))
+	var scene = new(BasicScene)
+	for _, object := range []Object{newUnitMesh(0, 0, 0), NewAmbientLight(core.Vec3(0.2, 0.2, 0.2), 1), sun, lamp} {
+		scene.Add(object)
+	}
+
+	var r = renderer.NullRenderer()
+	if err := r.Init(100, 100); err != nil {
+		t.Fatal(err)
+	}
+	scene.Render(r, camera)
+	if stats := scene.RenderStats(); stats.Drawn != 1 {
+		t.Fatalf("got %d drawn, want 1", stats.Drawn)
+	}
+	var block = scene.renderList.lightsBlock(camera.View())
+	if block.AmbientColor != core.Vec3(0.2, 0.2, 0.2) {
+		t.Errorf("ambient color = %v", block.AmbientColor)
+	}
+	if block.NumDirectional != 1 || block.Directional[0].Direction != core.Vec3(0, 1, 0) || block.Directional[0].Color != core.Vec3(0.5, 0.5, 0.5) {
+		t.Errorf("directional lights = %d %+v", block.NumDirectional, block.Directional[0])
+	}
+	// the lamp is 5 units in front of the camera
+	if block.NumPoint != 1 || block.Point[0].Position != core.Vec3(0, 0, -5) || block.Point[0].Color != core.Vec3(2, 0, 0) {
+		t.Errorf("point lights = %d %+v", block.NumPoint, block.Point[0])
+	}
+
+	data, err := renderer.EncodeStd140(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// arrays start at 32 and elements are 32 and 48 bytes as std140 requires
+	if want := 32 + MaxDirectionalLights*32 + MaxPointLights*48; len(data) != want {
+		t.Errorf("block is %d bytes, want %d", len(data), want)
+	}
+}
@@ -27,13 +27,27 @@ func (mesh *Mesh) String() string {
 	return "Mesh.String:TODO"
 }
 
+// Geometry returns the geometry of mesh
+func (mesh *Mesh) Geometry() geometry.Geometry {
+	return mesh.geometry
+}
+
+// Material returns the material of mesh
+func (mesh *Mesh) Material() material.Material {
+	return mesh.material
+}
+
 // Bounds implements Object Bounds method
 func (mesh *Mesh) Bounds() geometry.Box3 {
 	return mesh.geometry.Bounds()
 }
 
+// prepareProgram implements programHolder prepareProgram method
+func (mesh *Mesh) prepareProgram(renderer renderer.Renderer) {
+	mesh.object3d.resolveProgram(renderer, mesh.material)
+}
+
 // Render implements Object Render method
 func (mesh *Mesh) Render(renderer renderer.Renderer, proj, view, transform core.Matrix4) {
-	mesh.object3d.Render(renderer, proj, view, transform)
-	mesh.object3d.renderGeometry(renderer, mesh.geometry, mesh.material)
+	mesh.object3d.renderGeometry(renderer, mesh.geometry, mesh.material, proj, view, transform)
 }
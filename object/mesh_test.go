@@ -0,0 +1,49 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/driver/renderer/shader"
+	"github.com/gopherd/three/material"
+)
+
+// uniformRecorder records names of uniforms set on each program
+type uniformRecorder struct {
+	renderer.Renderer
+	uniforms map[uint32]map[string]bool
+}
+
+func (r *uniformRecorder) SetUniform(program uint32, name string, uniform shader.Uniform) error {
+	if r.uniforms[program] == nil {
+		r.uniforms[program] = make(map[string]bool)
+	}
+	r.uniforms[program][name] = true
+	return r.Renderer.SetUniform(program, name, uniform)
+}
+
+func TestMeshMatrixUniforms(t *testing.T) {
+	var r = &uniformRecorder{Renderer: renderer.NullRenderer(), uniforms: make(map[uint32]map[string]bool)}
+	if err := r.Init(100, 100); err != nil {
+		t.Fatal(err)
+	}
+	var mesh = newUnitMesh(0, 0, 0)
+	var m = mesh.Material().(*material.MeshBasicMaterial)
+	for i, step := range []string{"first draw", "recompiled"} {
+		if i > 0 {
+			m.Parameters().Options.VertexColors = true
+			m.SetNeedsUpdate(true)
+		}
+		var transform = mesh.Transform()
+		mesh.Render(r, transform, transform, transform)
+		var program = mesh.programId()
+		if program == 0 {
+			t.Fatalf("%s: no program", step)
+		}
+		for _, name := range []string{"proj", "view", "transform", "normalMatrix"} {
+			if !r.uniforms[program][name] {
+				t.Errorf("%s: uniform %s not set on program %d", step, name, program)
+			}
+		}
+	}
+}
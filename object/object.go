@@ -2,10 +2,12 @@ package object
 
 import (
 	"bytes"
+	"fmt"
 	"sync/atomic"
 
 	"github.com/gopherd/doge/container"
 	"github.com/gopherd/doge/container/stringify"
+	"github.com/gopherd/doge/math/mathutil"
 	"github.com/gopherd/three/core"
 	"github.com/gopherd/three/core/event"
 	"github.com/gopherd/three/driver/renderer"
@@ -26,6 +28,8 @@ type Object interface {
 	SetTag(tag string) // SetTag sets tag of Object
 
 	Visible() bool                          // Visible reports whether the object is visible
+	RenderOrder() int                       // RenderOrder returns the order overriding sorting of render list
+	Layers() Layers                         // Layers returns layers the object belongs to
 	Bounds() geometry.Box3                  // Bounds returns object bounding box
 	Transform() core.Matrix4                // Transform returns transform matrix in local space
 	TransformWorld() core.Matrix4           // TransformWorld returns transform matrix in world space
@@ -41,6 +45,7 @@ type node interface {
 
 	addChild(child Object)
 	setParent(parent Object)
+	unlinkChild(child Object) bool
 
 	RemoveChild(child Object) bool     // RemoveChild removes child object
 	RemoveChildByIndex(i int)          // RemoveChildByIndex removes ith child object
@@ -84,7 +89,7 @@ func (node *node3d) setParent(parent Object) {
 // addChild implements Object unexported addChild method
 func (node *node3d) addChild(child Object) {
 	if parent := child.Parent(); parent != nil {
-		parent.RemoveChild(child)
+		parent.unlinkChild(child)
 	}
 	if node.byUUID == nil {
 		node.byUUID = make(map[int64]int)
@@ -102,10 +107,30 @@ func (node *node3d) addChild(child Object) {
 		node.byTag[tag] = index
 	}
 	node.children = append(node.children, child)
+	core.LogScene.Debug("object added", "object", fmt.Sprintf("%T", child), "uuid", uuid)
 	child.DispatchEvent(addedEvent)
 }
 
+// unlinkChild implements Object unexported unlinkChild method, it removes
+// child which is moved to another parent, programs of child are kept
+func (node *node3d) unlinkChild(child Object) bool {
+	index, ok := node.byUUID[child.UUID()]
+	if !ok {
+		return false
+	}
+	node.unlink(index, child)
+	return true
+}
+
+// removeChild removes child from the tree, DetachedEvent is dispatched to
+// child and its descendants, and their programs are released
 func (node *node3d) removeChild(i int, child Object) {
+	node.unlink(i, child)
+	core.LogScene.Debug("object removed", "object", fmt.Sprintf("%T", child), "uuid", child.UUID())
+	recursivelyDetach(child)
+}
+
+func (node *node3d) unlink(i int, child Object) {
 	parent := child.Parent()
 	if parent != nil {
 		child.setParent(nil)
@@ -194,17 +219,34 @@ func (node *node3d) GetChildByUUID(uuid int64) Object {
 // OnUpdate implements Object OnUpdate method
 func (node *node3d) OnUpdate() {}
 
+// programSource identifies sources and variant of a program
+type programSource struct {
+	vertex   string
+	fragment string
+	variant  string
+}
+
 type object3d struct {
 	node3d
 	uuid    int64
 	tag     string
 	program struct {
 		renderer.Program
-		created bool
-		fail    bool
+		renderer renderer.Renderer
+		source   programSource
+		failed   programSource
+		err      error
+		created  bool
+		fail     bool
 	}
-	invisible bool
-	transform struct {
+	vertexArray struct {
+		id       uint32
+		geometry geometry.Geometry
+	}
+	invisible   bool
+	renderOrder int
+	layers      Layers
+	transform   struct {
 		position       core.Vector3
 		scale          core.Vector3
 		rotation       core.Euler
@@ -222,8 +264,11 @@ type object3d struct {
 func (obj *object3d) Init() {
 	obj.uuid = atomic.AddInt64(&nextObjectUUID, 1)
 	obj.transform.matrix.MakeIdentity()
+	obj.transform.scale = core.Vec3(1, 1, 1)
+	obj.transform.quaternion = core.IdentityQuaternion()
+	obj.transform.notNeedsUpdate = true
 	obj.transformWorld.matrix.MakeIdentity()
-	// TODO: update position, scale, rotation, quaternion
+	obj.layers = DefaultLayers
 }
 
 func (obj *object3d) String() string {
@@ -254,17 +299,43 @@ func (obj *object3d) SetVisible(visible bool) {
 	obj.invisible = !visible
 }
 
-// Transform implements Object Transform method
+// Layers implements Object Layers method
+func (obj *object3d) Layers() Layers {
+	return obj.layers
+}
+
+// SetLayers sets layers the object belongs to, children are not affected
+func (obj *object3d) SetLayers(layers Layers) {
+	obj.layers = layers
+}
+
+// RenderOrder implements Object RenderOrder method
+func (obj *object3d) RenderOrder() int {
+	return obj.renderOrder
+}
+
+// SetRenderOrder sets object render order, objects with lower render order
+// are rendered first, regardless of depth and program
+func (obj *object3d) SetRenderOrder(renderOrder int) {
+	obj.renderOrder = renderOrder
+}
+
+// Transform implements Object Transform method, the matrix is composed of
+// position, quaternion and scale if any of them changed
 func (obj *object3d) Transform() core.Matrix4 {
+	if !obj.transform.notNeedsUpdate {
+		obj.transform.notNeedsUpdate = true
+		obj.transform.matrix.Compose(obj.transform.position, obj.transform.quaternion, obj.transform.scale)
+	}
 	return obj.transform.matrix
 }
 
 // TransformWorld implements Object TransformWorld method
 func (obj *object3d) TransformWorld() core.Matrix4 {
 	if obj.parent == nil {
-		return obj.transform.matrix
+		return obj.Transform()
 	}
-	return obj.parent.TransformWorld().Dot(obj.transform.matrix)
+	return obj.parent.TransformWorld().Dot(obj.Transform())
 }
 
 func (obj object3d) GetPosition() core.Vector3 {
@@ -291,6 +362,7 @@ func (obj object3d) GetRotation() core.Euler {
 
 func (obj *object3d) SetRotation(euler core.Euler) {
 	obj.transform.rotation = euler
+	obj.transform.quaternion = core.QuaternionFromEuler(euler)
 	obj.transform.notNeedsUpdate = false
 }
 
@@ -300,6 +372,9 @@ func (obj object3d) GetQuaternion() core.Vector4 {
 
 func (obj *object3d) SetQuaternion(quaternion core.Vector4) {
 	obj.transform.quaternion = quaternion
+	var m = core.One4x4()
+	m.Compose(core.Vec3(0, 0, 0), quaternion, core.Vec3(1, 1, 1))
+	obj.transform.rotation.SetFromRotationMatrix(m)
 	obj.transform.notNeedsUpdate = false
 }
 
@@ -308,54 +383,209 @@ func (obj *object3d) LocalToWorld(vec core.Vector3) core.Vector3 {
 	return obj.TransformWorld().DotVec3(vec)
 }
 
-// TODO: LookAt implements Object LookAt method
+// LookAt implements Object LookAt method, the +Z axis of object points to
+// the position
 func (obj *object3d) LookAt(pos core.Vector3) {
+	obj.lookAt(pos, obj.TransformWorld().GetPosition())
 }
 
-func (obj *object3d) createProgram(renderer renderer.Renderer, shader shader.Shader) error {
-	program, err := renderer.CreateProgram(shader.Vertex, shader.Fragment)
+// lookAt rotates the object so that its -Z axis points from eye to target
+// in world space
+func (obj *object3d) lookAt(eye, target core.Vector3) {
+	var q = core.QuaternionLookAt(eye, target, core.Vec3(0, 1, 0))
+	if obj.parent != nil {
+		var parent = obj.parent.TransformWorld().GetQuaternion()
+		q = core.QuaternionMul(core.Vec4(-parent.X(), -parent.Y(), -parent.Z(), parent.W()), q)
+	}
+	obj.SetQuaternion(q)
+}
+
+// programId implements programHolder programId method
+func (obj *object3d) programId() uint32 {
+	return obj.program.Id
+}
+
+// updateProgram acquires program if sources or variant of the shader
+// changed, the previous program stays active if the new one fails to compile
+func (obj *object3d) updateProgram(renderer renderer.Renderer, source shader.Shader) error {
+	var key = programSource{
+		vertex:   source.Vertex,
+		fragment: source.Fragment,
+		variant:  source.Key(),
+	}
+	if obj.program.created && obj.program.source == key {
+		return nil
+	}
+	if obj.program.fail && obj.program.failed == key {
+		return nil
+	}
+	program, err := renderer.AcquireProgram(source)
 	if err != nil {
-		obj.program.fail = false
+		core.LogResource.Warn("program acquire failed", "object", obj.UUID(), "keep", obj.program.Id, "error", err)
+		obj.program.fail = true
+		obj.program.failed = key
+		if obj.program.created {
+			obj.program.err = err
+			shader.ReportReloadError(err)
+			return nil
+		}
 		return err
 	}
+	obj.releaseProgram()
+	core.LogResource.Debug("program acquired", "object", obj.UUID(), "id", program.Id)
 	obj.program.created = true
 	obj.program.Program = program
+	obj.program.renderer = renderer
+	obj.program.source = key
 	return nil
 }
 
-// Render implements Object Render method
-func (obj *object3d) Render(renderer renderer.Renderer, proj, view, transform core.Matrix4) {
-	renderer.SetUniform(obj.program.Id, "proj", proj)
-	renderer.SetUniform(obj.program.Id, "view", view)
-	renderer.SetUniform(obj.program.Id, "transform", transform)
+// releaseProgram releases the shared program and the vertex array bound to
+// it, they will be created again on next rendering
+func (obj *object3d) releaseProgram() {
+	if obj.program.created {
+		if obj.vertexArray.id != 0 {
+			obj.program.renderer.DeleteVertexArray(obj.vertexArray.id)
+		}
+		obj.program.renderer.ReleaseProgram(obj.program.Program)
+		core.LogResource.Debug("program released", "object", obj.UUID(), "id", obj.program.Id)
+	}
+	obj.vertexArray.id = 0
+	obj.vertexArray.geometry = nil
+	obj.program.Program = renderer.Program{}
+	obj.program.renderer = nil
+	obj.program.source = programSource{}
+	obj.program.failed = programSource{}
+	obj.program.err = nil
+	obj.program.created = false
+	obj.program.fail = false
+}
+
+// reportProgramError records and reports the error once, failed sources
+// are not compiled again until changed
+func (obj *object3d) reportProgramError(err error) {
+	if obj.program.err != nil && obj.program.err.Error() == err.Error() {
+		return
+	}
+	obj.program.err = err
+	core.ReportError(fmt.Errorf("object: %w", err))
+}
+
+// ProgramError returns the last error of compiling or linking the program
+// of object, the object is not rendered until the shader is fixed if it
+// has no valid program
+func (obj *object3d) ProgramError() error {
+	return obj.program.err
+}
+
+// Render implements Object Render method, object3d draws nothing
+func (obj *object3d) Render(renderer renderer.Renderer, proj, view, transform core.Matrix4) {}
+
+func (obj *object3d) setUniform(renderer renderer.Renderer, name string, uniform shader.Uniform) {
+	if err := renderer.SetUniform(obj.program.Id, name, uniform); err != nil {
+		obj.reportProgramError(err)
+	}
+}
+
+// resolveProgram resolves the shader of material and updates the program,
+// it reports false if the object has no valid program
+func (obj *object3d) resolveProgram(renderer renderer.Renderer, material material.Material) (shader.Shader, bool) {
+	shader, err := material.Shader().Resolve()
+	if err != nil {
+		obj.reportProgramError(err)
+	} else if err := obj.updateProgram(renderer, shader); err != nil {
+		obj.reportProgramError(err)
+	}
+	return shader, obj.program.created
 }
 
 func (obj *object3d) renderGeometry(
 	renderer renderer.Renderer,
 	geometry geometry.Geometry,
 	material material.Material,
+	proj, view, transform core.Matrix4,
 ) {
-	var shader = material.Shader()
-	if !obj.program.created && !obj.program.fail {
-		if err := obj.createProgram(renderer, shader); err != nil {
-			panic(err)
-		}
+	shader, ok := obj.resolveProgram(renderer, material)
+	if !ok {
+		return
 	}
-	var needsUpdate = material.NeedsUpdate()
-	if needsUpdate {
-		material.SetNeedsUpdate(false)
-		for name, uniform := range shader.Uniforms {
-			renderer.SetUniform(obj.program.Id, name, uniform)
-		}
+	renderer.SetState(material.Options().RenderState())
+	// matrices are set after the program is resolved, so they reach the
+	// program created or swapped by this draw
+	renderer.UseProgram(obj.program.Id)
+	obj.setUniform(renderer, "proj", proj)
+	obj.setUniform(renderer, "view", view)
+	obj.setUniform(renderer, "transform", transform)
+	obj.setUniform(renderer, "normalMatrix", core.NormalMatrix(view.Dot(transform)))
+	// programs may be shared by materials, so uniforms are set on every draw
+	// and unchanged values are skipped by the value cache of renderer
+	for name, uniform := range shader.Uniforms {
+		obj.setUniform(renderer, name, uniform)
+	}
+
+	obj.updateVertexArray(renderer, geometry)
+	first, count := drawRange(geometry)
+	renderer.Draw(obj.vertexArray.id, meshDrawMode, first, count)
+}
+
+// updateVertexArray uploads attributes of geometry which are used by the
+// program, all attributes are uploaded if the vertex array is new
+func (obj *object3d) updateVertexArray(renderer renderer.Renderer, geometry geometry.Geometry) {
+	var rebuild = obj.vertexArray.id == 0 || obj.vertexArray.geometry != geometry
+	if obj.vertexArray.id == 0 {
+		obj.vertexArray.id = renderer.CreateVertexArray()
+	}
+	if !rebuild && !geometry.NeedsUpdate() {
+		return
 	}
+	geometry.SetNeedsUpdate(false)
+	obj.vertexArray.geometry = geometry
 
 	var attributes = geometry.Attributes()
-	var index = geometry.Index()
-	if geometry.NeedsUpdate() {
-		geometry.SetNeedsUpdate(false)
-		// TODO: update attributes
+	for _, info := range renderer.Attributes(obj.program.Id) {
+		attribute, ok := attributes[info.Name]
+		if !ok || info.Location < 0 {
+			continue
+		}
+		if !rebuild && !attribute.NeedsUpdate() {
+			continue
+		}
+		attribute.SetNeedsUpdate(false)
+		if err := renderer.SetVertexAttribute(obj.vertexArray.id, vertexAttribute(info, attribute)); err != nil {
+			obj.reportProgramError(fmt.Errorf("attribute %s: %w", info.Name, err))
+		}
+	}
+	if index := geometry.Index(); index != nil && (rebuild || index.NeedsUpdate()) {
+		index.SetNeedsUpdate(false)
+		renderer.SetIndices(obj.vertexArray.id, index.Slice())
+	}
+}
+
+// meshDrawMode is the primitive mode geometries of meshes are drawn with
+const meshDrawMode = renderer.Triangles
+
+func vertexAttribute(info renderer.AttributeInfo, attribute geometry.Attribute) renderer.VertexAttribute {
+	return renderer.VertexAttribute{
+		Location: uint32(info.Location),
+		Size:     attribute.Stride(),
+		Data:     attribute.Data(),
 	}
-	_, _ = attributes, index
+}
+
+// drawRange returns range of vertices or indices to draw, the whole
+// geometry is drawn if the draw range is empty
+func drawRange(geo geometry.Geometry) (first, count int) {
+	if index := geo.Index(); index != nil {
+		count = index.Count() * index.Stride()
+	} else if position, ok := geo.Attributes()[geometry.AttributePosition]; ok {
+		count = position.Count()
+	}
+	var r = geo.DrawRange()
+	if r.End > r.Start {
+		first = r.Start
+		count = mathutil.Min(r.End, count) - first
+	}
+	return
 }
 
 // Attatch attatchs child to parent object
@@ -364,40 +594,19 @@ func Attatch(parent, child Object) {
 	child.setParent(parent)
 }
 
-func recursivelyRenderObject(
-	renderer renderer.Renderer,
-	camera Camera,
-	proj, view core.Matrix4,
-	object Object,
-	transform core.Matrix4,
-) {
-	renderObject(renderer, camera, proj, view, object, transform)
-	for i, n := 0, object.NumChild(); i < n; i++ {
-		child := object.GetChildByIndex(i)
-		if !child.Visible() {
-			continue
-		}
-		childTransform := transform.Dot(child.Transform())
-		recursivelyRenderObject(renderer, camera, proj, view, child, childTransform)
-	}
+// programReleaser is implemented by objects which own a shared program
+type programReleaser interface {
+	releaseProgram()
 }
 
-func renderObject(
-	renderer renderer.Renderer,
-	camera Camera,
-	proj, view core.Matrix4,
-	object Object,
-	transform core.Matrix4,
-) {
-	box := object.Bounds()
-	if !box.IsEmpty() {
-		box.Min = transform.DotVec3(box.Min)
-		box.Max = transform.DotVec3(box.Max)
-		if !camera.IntersectsBox(box) {
-			return
-		}
+func recursivelyDetach(object Object) {
+	object.DispatchEvent(detachedEvent)
+	if releaser, ok := object.(programReleaser); ok {
+		releaser.releaseProgram()
+	}
+	for i, n := 0, object.NumChild(); i < n; i++ {
+		recursivelyDetach(object.GetChildByIndex(i))
 	}
-	object.Render(renderer, proj, view, transform)
 }
 
 func recursivelyUpdateNode(node node) {
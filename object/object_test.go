@@ -0,0 +1,36 @@
+package object
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer"
+)
+
+func TestObjectLogs(t *testing.T) {
+	var logs strings.Builder
+	core.SetLogger(core.NewLogger(&logs, core.LevelDebug))
+	defer core.SetLogger(nil)
+
+	var r = renderer.NullRenderer()
+	if err := r.Init(100, 100); err != nil {
+		t.Fatal(err)
+	}
+	var scene = new(BasicScene)
+	var mesh = newUnitMesh(0, 0, 0)
+	scene.Add(mesh)
+	var transform = mesh.Transform()
+	mesh.Render(r, transform, transform, transform)
+	scene.RemoveChild(mesh)
+	for _, want := range []string{
+		"object added category=scene object=*object.Mesh",
+		"program acquired category=resource",
+		"object removed category=scene object=*object.Mesh",
+		"program released category=resource",
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("missing log %q in:\n%s", want, logs.String())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package object
+
+import (
+	"github.com/gopherd/three/core/event"
+	"github.com/gopherd/three/input"
+)
+
+// PointerEvent holds common fields of pointer events, pointer events are
+// dispatched as pointers so listeners can stop propagation
+type PointerEvent struct {
+	Target        Object // Target is the object under the pointer
+	CurrentTarget Object // CurrentTarget is the object whose listeners are called
+	Button        input.MouseButton
+	Mods          input.ModifierKey
+	X, Y          float64 // X, Y is cursor position in screen coordinates
+	Hit           Intersection
+
+	stopped bool
+}
+
+// StopPropagation stops bubbling the event to parents of current target
+func (e *PointerEvent) StopPropagation() {
+	e.stopped = true
+}
+
+// Stopped reports whether propagation of the event is stopped
+func (e *PointerEvent) Stopped() bool {
+	return e.stopped
+}
+
+func (e *PointerEvent) pointerEvent() *PointerEvent { return e }
+
+type (
+	// PointerDownEvent is fired when a mouse button is pressed on an object
+	PointerDownEvent struct{ PointerEvent }
+	// PointerUpEvent is fired when a mouse button is released on an object
+	PointerUpEvent struct{ PointerEvent }
+	// ClickEvent is fired when a mouse button is pressed and released on the same object
+	ClickEvent struct{ PointerEvent }
+	// PointerMoveEvent is fired when the pointer moves over an object
+	PointerMoveEvent struct{ PointerEvent }
+	// PointerEnterEvent is fired when the pointer enters an object, it doesn't bubble
+	PointerEnterEvent struct{ PointerEvent }
+	// PointerLeaveEvent is fired when the pointer leaves an object, it doesn't bubble
+	PointerLeaveEvent struct{ PointerEvent }
+)
+
+// PointerCaptureEvent is fired in capture phase of a pointer event, it's
+// dispatched to the scene and ancestors of the target from top to bottom
+// before the event reaches the target. Listeners may stop propagation to
+// intercept the event
+type PointerCaptureEvent struct {
+	*PointerEvent             // PointerEvent is shared with the captured event
+	Event         event.Event // Event is the captured event, e.g. *PointerDownEvent
+}
+
+//@mod:final
+var (
+	PointerDownEventType  = event.TypeOf[*PointerDownEvent](nil)
+	PointerUpEventType    = event.TypeOf[*PointerUpEvent](nil)
+	ClickEventType        = event.TypeOf[*ClickEvent](nil)
+	PointerMoveEventType  = event.TypeOf[*PointerMoveEvent](nil)
+	PointerEnterEventType = event.TypeOf[*PointerEnterEvent](nil)
+	PointerLeaveEventType = event.TypeOf[*PointerLeaveEvent](nil)
+
+	PointerCaptureEventType = event.TypeOf[*PointerCaptureEvent](nil)
+)
+
+func (*PointerDownEvent) Type() event.Type  { return PointerDownEventType }
+func (*PointerUpEvent) Type() event.Type    { return PointerUpEventType }
+func (*ClickEvent) Type() event.Type        { return ClickEventType }
+func (*PointerMoveEvent) Type() event.Type  { return PointerMoveEventType }
+func (*PointerEnterEvent) Type() event.Type { return PointerEnterEventType }
+func (*PointerLeaveEvent) Type() event.Type { return PointerLeaveEventType }
+
+func (*PointerCaptureEvent) Type() event.Type { return PointerCaptureEventType }
+
+type pointerEvent interface {
+	event.Event
+	pointerEvent() *PointerEvent
+}
+
+// DispatchPointerEvent dispatches the event in capture phase as
+// PointerCaptureEvent to the scene and ancestors of the target from top to
+// bottom, then to the target and bubbles it up to parents of the target,
+// the scene receives the event finally. Dispatching ends once propagation
+// stopped in any phase
+func DispatchPointerEvent(scene Scene, e pointerEvent) {
+	var pe = e.pointerEvent()
+	capturePointerEvent(scene, e, pe)
+	for object := pe.Target; object != nil && !pe.stopped; object = object.Parent() {
+		pe.CurrentTarget = object
+		object.DispatchEvent(e)
+	}
+	pe.CurrentTarget = nil
+	if !pe.stopped && scene != nil {
+		scene.DispatchEvent(e)
+	}
+}
+
+// capturePointerEvent dispatches the event in capture phase
+func capturePointerEvent(scene Scene, e event.Event, pe *PointerEvent) {
+	var ancestors []Object
+	if pe.Target != nil {
+		for object := pe.Target.Parent(); object != nil; object = object.Parent() {
+			ancestors = append(ancestors, object)
+		}
+	}
+	var capture = &PointerCaptureEvent{PointerEvent: pe, Event: e}
+	if scene != nil {
+		scene.DispatchEvent(capture)
+	}
+	for i := len(ancestors) - 1; i >= 0 && !pe.stopped; i-- {
+		pe.CurrentTarget = ancestors[i]
+		ancestors[i].DispatchEvent(capture)
+	}
+	pe.CurrentTarget = nil
+}
@@ -0,0 +1,58 @@
+package object
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gopherd/three/core/event"
+)
+
+func TestDispatchPointerEvent(t *testing.T) {
+	var scene = new(BasicScene)
+	var outer, inner, target = NewGroup(), NewGroup(), newUnitMesh(0, 0, 0)
+	outer.Add(inner)
+	inner.Add(target)
+	scene.Add(outer)
+
+	type listenable interface {
+		node
+		AddEventListener(event.Listener) int
+	}
+	var names = map[listenable]string{scene: "scene", outer: "outer", inner: "inner", target: "target"}
+	var calls []string
+	var stopAt string
+	for n, name := range names {
+		var n, name = n, name
+		n.AddEventListener(event.Listen(PointerCaptureEventType, func(e *PointerCaptureEvent) {
+			if _, ok := e.Event.(*ClickEvent); !ok {
+				t.Errorf("captured %T, want *ClickEvent", e.Event)
+			}
+			calls = append(calls, "capture "+name)
+			if name == stopAt {
+				e.StopPropagation()
+			}
+		}))
+		n.AddEventListener(event.Listen(ClickEventType, func(e *ClickEvent) {
+			calls = append(calls, "bubble "+name)
+			if e.CurrentTarget != nil && any(e.CurrentTarget) != n {
+				t.Errorf("current target of %s is %v", name, e.CurrentTarget)
+			}
+		}))
+	}
+	for _, tt := range []struct {
+		stopAt string
+		want   []string
+	}{
+		{want: []string{
+			"capture scene", "capture outer", "capture inner",
+			"bubble target", "bubble inner", "bubble outer", "bubble scene",
+		}},
+		{stopAt: "outer", want: []string{"capture scene", "capture outer"}},
+	} {
+		calls, stopAt = nil, tt.stopAt
+		DispatchPointerEvent(scene, &ClickEvent{PointerEvent{Target: target}})
+		if !reflect.DeepEqual(calls, tt.want) {
+			t.Errorf("stop at %q: got %q, want %q", tt.stopAt, calls, tt.want)
+		}
+	}
+}
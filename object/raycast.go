@@ -0,0 +1,140 @@
+package object
+
+import (
+	"sort"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/geometry"
+	"github.com/gopherd/three/material"
+)
+
+// Face represents a triangle of geometry hit by a ray
+type Face struct {
+	A, B, C int          // A, B, C are indices of vertices
+	Normal  core.Vector3 // Normal in local space
+}
+
+// Intersection describes a hit of ray on an object
+type Intersection struct {
+	Object    Object
+	Distance  core.Float   // Distance from origin of ray in world space
+	Point     core.Vector3 // Point of intersection in world space
+	Face      Face
+	FaceIndex int
+}
+
+// raycaster is implemented by objects which can be hit by rays
+type raycaster interface {
+	raycast(ray geometry.Ray, transform core.Matrix4, intersections []Intersection) []Intersection
+}
+
+// RayFromCamera returns the ray from camera through the point (x, y) in
+// normalized device coordinates
+func RayFromCamera(camera Camera, x, y core.Float) geometry.Ray {
+	var inverse = camera.Projection().Dot(camera.View()).Invert()
+	var near = unproject(inverse, core.Vec3(x, y, -1))
+	var far = unproject(inverse, core.Vec3(x, y, 1))
+	return geometry.Ray{Origin: near, Direction: far.Sub(near).Normalize()}
+}
+
+func unproject(inverse core.Matrix4, ndc core.Vector3) core.Vector3 {
+	var v = inverse.DotVec4(ndc.Vec4())
+	return core.Vec3(v.X()/v.W(), v.Y()/v.W(), v.Z()/v.W())
+}
+
+// Raycast returns intersections of ray with visible objects under node
+// sorted by distance
+func Raycast(node node, ray geometry.Ray) []Intersection {
+	return RaycastLayers(node, ray, AllLayers)
+}
+
+// RaycastLayers returns intersections of ray with visible objects in layers
+// under node sorted by distance, e.g. picking by camera uses its layer mask
+func RaycastLayers(node node, ray geometry.Ray, layers Layers) []Intersection {
+	var intersections []Intersection
+	for i, n := 0, node.NumChild(); i < n; i++ {
+		var child = node.GetChildByIndex(i)
+		if child.Visible() {
+			intersections = recursivelyRaycast(child, ray, layers, child.Transform(), intersections)
+		}
+	}
+	sort.SliceStable(intersections, func(i, j int) bool {
+		return intersections[i].Distance < intersections[j].Distance
+	})
+	return intersections
+}
+
+func recursivelyRaycast(object Object, ray geometry.Ray, layers Layers, transform core.Matrix4, intersections []Intersection) []Intersection {
+	if r, ok := object.(raycaster); ok && object.Layers().Test(layers) {
+		intersections = r.raycast(ray, transform, intersections)
+	}
+	for i, n := 0, object.NumChild(); i < n; i++ {
+		var child = object.GetChildByIndex(i)
+		if child.Visible() {
+			intersections = recursivelyRaycast(child, ray, layers, transform.Dot(child.Transform()), intersections)
+		}
+	}
+	return intersections
+}
+
+// raycast implements raycaster raycast method
+func (mesh *Mesh) raycast(ray geometry.Ray, transform core.Matrix4, intersections []Intersection) []Intersection {
+	var positions, ok = mesh.geometry.Attributes()[geometry.AttributePosition]
+	if !ok || positions.Stride() < 3 {
+		return intersections
+	}
+	var inverse = transform.Invert()
+	var local = ray.ApplyMatrix4(inverse)
+	if box := mesh.geometry.Bounds(); !box.IsEmpty() {
+		if _, ok := local.IntersectBox(box); !ok {
+			return intersections
+		}
+	}
+	var backfaceCulling = mesh.material == nil || mesh.material.Options().Side == material.FrontSide
+	var vertex = func(i int) core.Vector3 {
+		var offset = i * positions.Stride()
+		return core.Vec3(positions.Float(offset), positions.Float(offset+1), positions.Float(offset+2))
+	}
+
+	var nearest = -1
+	var hit Intersection
+	var visit = func(face, a, b, c int) {
+		var va, vb, vc = vertex(a), vertex(b), vertex(c)
+		t, ok := local.IntersectTriangle(va, vb, vc, backfaceCulling)
+		if !ok {
+			return
+		}
+		var point = transform.DotVec3(local.At(t))
+		var distance = point.Sub(ray.Origin).Length()
+		if nearest >= 0 && distance >= hit.Distance {
+			return
+		}
+		nearest = face
+		hit = Intersection{
+			Object:   mesh,
+			Distance: distance,
+			Point:    point,
+			Face: Face{
+				A:      a,
+				B:      b,
+				C:      c,
+				Normal: vc.Sub(vb).Cross(va.Sub(vb)).Normalize(),
+			},
+			FaceIndex: face,
+		}
+	}
+	if index := mesh.geometry.Index(); index != nil {
+		var indices = index.Slice()
+		for i := 0; i+2 < len(indices); i += 3 {
+			visit(i/3, int(indices[i]), int(indices[i+1]), int(indices[i+2]))
+		}
+	} else {
+		for i := 0; i+2 < positions.Count(); i += 3 {
+			visit(i/3, i, i+1, i+2)
+		}
+	}
+	if nearest >= 0 {
+		intersections = append(intersections, hit)
+	}
+	return intersections
+}
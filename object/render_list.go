@@ -0,0 +1,207 @@
+package object
+
+import (
+	"sort"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/material"
+)
+
+// materialHolder is implemented by objects rendered with a material, only
+// objects with a material are collected to render lists
+type materialHolder interface {
+	Material() material.Material
+}
+
+// programHolder is implemented by objects which own a renderer program,
+// prepareProgram acquires the program before render lists are sorted
+type programHolder interface {
+	programId() uint32
+	prepareProgram(renderer renderer.Renderer)
+}
+
+type lightItem struct {
+	light     light
+	transform core.Matrix4
+}
+
+type renderItem struct {
+	id          int
+	object      Object
+	transform   core.Matrix4
+	z           core.Float
+	renderOrder int
+	program     uint32
+	state       int
+}
+
+// renderList collects visible objects of a frame, opaque objects are sorted
+// front-to-back and grouped by program and render state, transparent objects
+// are sorted back-to-front
+type renderList struct {
+	renderer    renderer.Renderer
+	opaque      []renderItem
+	transparent []renderItem
+	states      map[renderer.State]int // states maps render states to sort keys in order of appearance
+	lights      []lightItem
+	culled      int
+	layers      Layers // layers is the mask of layers to collect
+}
+
+// len returns number of collected objects
+func (list *renderList) len() int {
+	return len(list.opaque) + len(list.transparent)
+}
+
+func (list *renderList) reset(renderer renderer.Renderer, layers Layers) {
+	list.renderer = renderer
+	list.layers = layers
+	for state := range list.states {
+		delete(list.states, state)
+	}
+	for i := range list.opaque {
+		list.opaque[i].object = nil
+	}
+	for i := range list.transparent {
+		list.transparent[i].object = nil
+	}
+	for i := range list.lights {
+		list.lights[i].light = nil
+	}
+	list.opaque = list.opaque[:0]
+	list.transparent = list.transparent[:0]
+	list.lights = list.lights[:0]
+	list.culled = 0
+}
+
+func (list *renderList) push(object Object, material material.Material, transform core.Matrix4, projView core.Matrix4) {
+	var item = renderItem{
+		id:          list.len(),
+		object:      object,
+		transform:   transform,
+		z:           projView.DotVec3(transform.GetPosition()).Z(),
+		renderOrder: object.RenderOrder(),
+	}
+	if holder, ok := object.(programHolder); ok {
+		holder.prepareProgram(list.renderer)
+		item.program = holder.programId()
+	}
+	var options = material.Options()
+	if options.Transparent {
+		list.transparent = append(list.transparent, item)
+		return
+	}
+	item.state = list.stateKey(options.RenderState())
+	list.opaque = append(list.opaque, item)
+}
+
+// stateKey returns the sort key of state, keys are assigned in order of
+// appearance and valid within a frame
+func (list *renderList) stateKey(state renderer.State) int {
+	if key, ok := list.states[state]; ok {
+		return key
+	}
+	if list.states == nil {
+		list.states = make(map[renderer.State]int)
+	}
+	var key = len(list.states) + 1
+	list.states[state] = key
+	return key
+}
+
+func (list *renderList) sort() {
+	sort.Slice(list.opaque, func(i, j int) bool {
+		var a, b = &list.opaque[i], &list.opaque[j]
+		if a.renderOrder != b.renderOrder {
+			return a.renderOrder < b.renderOrder
+		}
+		if a.program != b.program {
+			return a.program < b.program
+		}
+		if a.state != b.state {
+			return a.state < b.state
+		}
+		if a.z != b.z {
+			return a.z < b.z
+		}
+		return a.id < b.id
+	})
+	sort.Slice(list.transparent, func(i, j int) bool {
+		var a, b = &list.transparent[i], &list.transparent[j]
+		if a.renderOrder != b.renderOrder {
+			return a.renderOrder < b.renderOrder
+		}
+		if a.z != b.z {
+			return a.z > b.z
+		}
+		return a.id < b.id
+	})
+}
+
+// lightsBlock returns the uniform block of collected lights in view space
+func (list *renderList) lightsBlock(view core.Matrix4) LightsBlock {
+	var block LightsBlock
+	for i := range list.lights {
+		list.lights[i].light.addTo(&block, view, list.lights[i].transform)
+	}
+	return block
+}
+
+func (list *renderList) render(renderer renderer.Renderer, proj, view core.Matrix4) {
+	for i := range list.opaque {
+		list.opaque[i].object.Render(renderer, proj, view, list.opaque[i].transform)
+	}
+	for i := range list.transparent {
+		list.transparent[i].object.Render(renderer, proj, view, list.transparent[i].transform)
+	}
+}
+
+func recursivelyCollectObject(
+	list *renderList,
+	camera Camera,
+	projView core.Matrix4,
+	object Object,
+	transform core.Matrix4,
+) {
+	collectObject(list, camera, projView, object, transform)
+	for i, n := 0, object.NumChild(); i < n; i++ {
+		child := object.GetChildByIndex(i)
+		if !child.Visible() {
+			continue
+		}
+		childTransform := transform.Dot(child.Transform())
+		recursivelyCollectObject(list, camera, projView, child, childTransform)
+	}
+}
+
+func collectObject(
+	list *renderList,
+	camera Camera,
+	projView core.Matrix4,
+	object Object,
+	transform core.Matrix4,
+) {
+	if light, ok := object.(light); ok {
+		list.lights = append(list.lights, lightItem{light: light, transform: transform})
+		return
+	}
+	// only objects with a material draw something, containers and user types
+	// embedding them are skipped while their children are collected by caller
+	holder, ok := object.(materialHolder)
+	if !ok || holder.Material() == nil {
+		return
+	}
+	if !object.Layers().Test(list.layers) {
+		return
+	}
+	box := object.Bounds()
+	if !box.IsEmpty() {
+		box = box.ApplyMatrix4(transform)
+		if !camera.IntersectsBox(box) {
+			list.culled++
+			return
+		}
+	}
+	list.push(object, holder.Material(), transform, projView)
+}
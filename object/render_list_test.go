@@ -0,0 +1,61 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/gopherd/three/core"
+	"github.com/gopherd/three/driver/renderer"
+	"github.com/gopherd/three/material"
+)
+
+func TestRenderListSortOpaque(t *testing.T) {
+	var camera = NewPerspectiveCamera(45, 1, 0.1, 100)
+	camera.SetPosition(core.Vec3(0, 0, 10))
+	camera.LookAt(core.Vec3(0, 0, 0))
+	var scene = new(BasicScene)
+	for i, options := range []material.Options{
+		{},
+		{VertexColors: true},
+		{Side: material.DoubleSide},
+		{VertexColors: true},
+		{},
+		{Side: material.DoubleSide},
+	} {
+		var mesh = newUnitMesh(0, 0, -core.Float(i))
+		mesh.material = material.NewMeshBasicMaterial(material.MeshBasicMaterialParameters{Options: options})
+		scene.Add(mesh)
+	}
+
+	var r = renderer.NullRenderer()
+	if err := r.Init(100, 100); err != nil {
+		t.Fatal(err)
+	}
+	// programs are acquired while collecting, so the first frame is sorted
+	scene.Render(r, camera)
+	var list = scene.renderList.opaque
+	if len(list) != 6 {
+		t.Fatalf("got %d opaque items, want 6", len(list))
+	}
+	type group struct {
+		program uint32
+		state   int
+	}
+	var seen = make(map[group]bool)
+	for i, item := range list {
+		if item.program == 0 {
+			t.Fatalf("item %d has no program", i)
+		}
+		var key = group{item.program, item.state}
+		if i > 0 && key == (group{list[i-1].program, list[i-1].state}) {
+			if item.z < list[i-1].z {
+				t.Fatalf("item %d is not sorted front-to-back", i)
+			}
+		} else if seen[key] {
+			t.Fatalf("item %d: program %d with state %d is not grouped", i, key.program, key.state)
+		}
+		seen[key] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("got %d groups of program and state, want 3", len(seen))
+	}
+}
@@ -1,6 +1,10 @@
 package object
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/gopherd/doge/operator"
 	"github.com/gopherd/three/core"
 	"github.com/gopherd/three/driver/renderer"
 )
@@ -13,11 +17,68 @@ type Scene interface {
 	Add(object Object)
 	// Render renders the scene by camera to renderer
 	Render(renderer renderer.Renderer, camera Camera)
+	// RenderPass renders the scene to renderer by the pass
+	RenderPass(renderer renderer.Renderer, pass RenderPass)
+	// RenderStats returns stats of the last rendering
+	RenderStats() RenderStats
 
 	// OnEnter callback called on enter the scene
 	OnEnter()
 	// OnExit callback called on exit the scene
 	OnExit()
+	// OnPause callback called when the scene is covered by a pushed scene
+	OnPause()
+	// OnResume callback called when the scene is uncovered by popping scenes
+	OnResume()
+	// OnEnterTransitionDidFinish callback called when the transition of
+	// entering or resuming the scene finished, it's called right after
+	// OnEnter or OnResume if no transition
+	OnEnterTransitionDidFinish()
+}
+
+// RenderStats holds counters and CPU timings of rendering a scene
+type RenderStats struct {
+	Culled int // Culled is number of objects culled by camera frustum
+	// Drawn is number of objects submitted to renderer, it's an upper bound
+	// of objects drawn since objects without a valid program draw nothing,
+	// see renderer.Stats for exact draw calls
+	Drawn int
+
+	Cull   time.Duration // Cull is time of collecting and culling objects
+	Sort   time.Duration // Sort is time of sorting render list
+	Submit time.Duration // Submit is time of submitting objects to renderer
+}
+
+// RenderPass holds options of rendering a scene once, a scene may be
+// rendered by multiple passes per frame, e.g. for split-screen views
+type RenderPass struct {
+	Camera Camera
+	// KeepColor disables clearing color buffer with background of scene
+	KeepColor bool
+	// KeepDepth disables clearing depth and stencil buffers
+	KeepDepth bool
+	// Layers selects objects to render in addition to the layer mask of
+	// camera, all layers of the mask if zero
+	Layers Layers
+}
+
+func (pass RenderPass) clearMask() renderer.ClearMask {
+	var mask renderer.ClearMask
+	if !pass.KeepColor {
+		mask |= renderer.ClearColorBuffer
+	}
+	if !pass.KeepDepth {
+		mask |= renderer.ClearDepthBuffer | renderer.ClearStencilBuffer
+	}
+	return mask
+}
+
+// CameraBlock is the uniform block `Camera' shared by all programs, it's
+// uploaded once per frame
+type CameraBlock struct {
+	Projection core.Matrix4
+	View       core.Matrix4
+	Position   core.Vector3
 }
 
 // Update updates scene
@@ -31,6 +92,9 @@ var _ Scene = (*BasicScene)(nil)
 type BasicScene struct {
 	node3d
 	background core.Vector4
+	camera     Camera
+	renderList renderList
+	stats      RenderStats
 }
 
 func (scene *BasicScene) String() string {
@@ -42,6 +106,17 @@ func (scene *BasicScene) SetBackground(color core.Vector4) {
 	scene.background = color
 }
 
+// Camera returns the camera of scene
+func (scene *BasicScene) Camera() Camera {
+	return scene.camera
+}
+
+// SetCamera sets the camera of scene, the scene is rendered by the camera
+// instead of the director's camera if it's not nil
+func (scene *BasicScene) SetCamera(camera Camera) {
+	scene.camera = camera
+}
+
 // Add implements Scene Add method
 func (scene *BasicScene) Add(object Object) {
 	scene.addChild(object)
@@ -49,16 +124,54 @@ func (scene *BasicScene) Add(object Object) {
 
 // Render implements Scene Render method
 func (scene *BasicScene) Render(renderer renderer.Renderer, camera Camera) {
+	scene.RenderPass(renderer, RenderPass{Camera: camera})
+}
+
+// RenderPass implements Scene RenderPass method
+func (scene *BasicScene) RenderPass(renderer renderer.Renderer, pass RenderPass) {
+	var camera = pass.Camera
 	var proj = camera.Projection()
-	var view = camera.TransformWorld()
+	var view = camera.View()
 	var background = scene.background
-	renderer.ClearColor(background.X(), background.Y(), background.Z(), background.W())
+	renderer.Clear(pass.clearMask(), background.X(), background.Y(), background.Z(), background.W())
+	if err := renderer.SetUniformBlock("Camera", CameraBlock{
+		Projection: proj,
+		View:       view,
+		Position:   camera.TransformWorld().GetPosition(),
+	}); err != nil {
+		core.ReportError(fmt.Errorf("scene: %w", err))
+	}
+
+	var projView = proj.Dot(view)
+	var list = &scene.renderList
+	var start = time.Now()
+	list.reset(renderer, camera.LayerMask()&operator.Or(pass.Layers, AllLayers))
 	for _, child := range scene.children {
 		if !child.Visible() {
 			continue
 		}
-		recursivelyRenderObject(renderer, camera, proj, view, child, child.Transform())
+		recursivelyCollectObject(list, camera, projView, child, child.Transform())
+	}
+	if err := renderer.SetUniformBlock("Lights", list.lightsBlock(view)); err != nil {
+		core.ReportError(fmt.Errorf("scene: %w", err))
 	}
+	var collected = time.Now()
+	list.sort()
+	var sorted = time.Now()
+	list.render(renderer, proj, view)
+
+	scene.stats = RenderStats{
+		Culled: list.culled,
+		Drawn:  list.len(),
+		Cull:   collected.Sub(start),
+		Sort:   sorted.Sub(collected),
+		Submit: time.Since(sorted),
+	}
+}
+
+// RenderStats implements Scene RenderStats method
+func (scene *BasicScene) RenderStats() RenderStats {
+	return scene.stats
 }
 
 // OnEnter implements Scene OnEnter method
@@ -66,3 +179,12 @@ func (scene *BasicScene) OnEnter() {}
 
 // OnExit implements Scene OnExit method
 func (scene *BasicScene) OnExit() {}
+
+// OnPause implements Scene OnPause method
+func (scene *BasicScene) OnPause() {}
+
+// OnResume implements Scene OnResume method
+func (scene *BasicScene) OnResume() {}
+
+// OnEnterTransitionDidFinish implements Scene OnEnterTransitionDidFinish method
+func (scene *BasicScene) OnEnterTransitionDidFinish() {}